@@ -0,0 +1,109 @@
+package workerbase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-nacelle/workerbase/internal/exponential"
+)
+
+// Jitter strategies that can be applied to the delay between failing ticks.
+const (
+	JitterNone         = "none"
+	JitterFull         = "full"
+	JitterEqual        = "equal"
+	JitterDecorrelated = "decorrelated"
+)
+
+// backoff computes the delay between consecutive failing ticks. The delay
+// grows exponentially from the initial delay by the given multiplier and
+// is capped at the max delay.
+type backoff struct {
+	initialDelay time.Duration
+	multiplier   float64
+	maxDelay     time.Duration
+	maxAttempts  int
+	jitter       string
+	random       func() float64
+	attempts     int
+	previous     time.Duration
+}
+
+func newBackoff(c *Config, random func() float64) *backoff {
+	return &backoff{
+		initialDelay: c.RetryInitialDelay,
+		multiplier:   c.RetryMultiplier,
+		maxDelay:     c.RetryMaxDelay,
+		maxAttempts:  c.RetryMaxAttempts,
+		jitter:       c.RetryJitter,
+		random:       random,
+	}
+}
+
+// clone returns a copy of the backoff with no recorded attempts. This method
+// returns nil if the receiver is nil.
+func (b *backoff) clone() *backoff {
+	if b == nil {
+		return nil
+	}
+
+	clone := *b
+	clone.reset()
+	return &clone
+}
+
+// next records a failed attempt and returns the delay to wait before the
+// next attempt. This method returns false if the maximum number of attempts
+// has been reached.
+func (b *backoff) next() (time.Duration, bool) {
+	b.attempts++
+	if b.maxAttempts > 0 && b.attempts >= b.maxAttempts {
+		return 0, false
+	}
+
+	delay := b.delay()
+	b.previous = delay
+	return delay, true
+}
+
+// reset clears the attempt counter after a successful tick.
+func (b *backoff) reset() {
+	b.attempts = 0
+	b.previous = 0
+}
+
+func (b *backoff) delay() time.Duration {
+	if b.jitter == JitterDecorrelated {
+		previous := b.previous
+		if previous == 0 {
+			previous = b.initialDelay
+		}
+
+		upper := exponential.Cap(float64(previous)*b.multiplier, b.maxDelay)
+		if upper <= b.initialDelay {
+			return upper
+		}
+
+		return b.initialDelay + time.Duration(b.random()*float64(upper-b.initialDelay))
+	}
+
+	base := exponential.Delay(b.initialDelay, b.multiplier, b.attempts, b.maxDelay)
+
+	switch b.jitter {
+	case JitterFull:
+		return time.Duration(b.random() * float64(base))
+	case JitterEqual:
+		return base/2 + time.Duration(b.random()*float64(base/2))
+	}
+
+	return base
+}
+
+func validateJitter(jitter string) error {
+	switch jitter {
+	case JitterNone, JitterFull, JitterEqual, JitterDecorrelated:
+		return nil
+	}
+
+	return fmt.Errorf("unknown retry jitter %q", jitter)
+}
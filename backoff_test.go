@@ -0,0 +1,78 @@
+package workerbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffDelays(t *testing.T) {
+	testCases := []struct {
+		name     string
+		jitter   string
+		expected []time.Duration
+	}{
+		{
+			name:     "none",
+			jitter:   JitterNone,
+			expected: []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 10, time.Second * 10},
+		},
+		{
+			name:     "full",
+			jitter:   JitterFull,
+			expected: []time.Duration{time.Second / 2, time.Second, time.Second * 2, time.Second * 4, time.Second * 5, time.Second * 5},
+		},
+		{
+			name:     "equal",
+			jitter:   JitterEqual,
+			expected: []time.Duration{time.Second * 3 / 4, time.Second * 3 / 2, time.Second * 3, time.Second * 6, time.Second * 15 / 2, time.Second * 15 / 2},
+		},
+		{
+			name:     "decorrelated",
+			jitter:   JitterDecorrelated,
+			expected: []time.Duration{time.Second * 3 / 2, time.Second * 2, time.Second * 5 / 2, time.Second * 3, time.Second * 7 / 2, time.Second * 4},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b := newBackoff(&Config{
+				RetryInitialDelay: time.Second,
+				RetryMultiplier:   2,
+				RetryMaxDelay:     time.Second * 10,
+				RetryJitter:       testCase.jitter,
+			}, func() float64 { return 0.5 })
+
+			var delays []time.Duration
+			for range testCase.expected {
+				delay, ok := b.next()
+				assert.True(t, ok)
+				delays = append(delays, delay)
+			}
+
+			assert.Equal(t, testCase.expected, delays)
+		})
+	}
+}
+
+func TestBackoffMaxAttempts(t *testing.T) {
+	b := newBackoff(&Config{
+		RetryInitialDelay: time.Second,
+		RetryMultiplier:   2,
+		RetryMaxAttempts:  3,
+		RetryJitter:       JitterNone,
+	}, nil)
+
+	_, ok := b.next()
+	assert.True(t, ok)
+	_, ok = b.next()
+	assert.True(t, ok)
+	_, ok = b.next()
+	assert.False(t, ok)
+
+	b.reset()
+	delay, ok := b.next()
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+}
@@ -0,0 +1,192 @@
+package workerbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/go-nacelle/workerbase/internal/atomicfile"
+)
+
+// Checkpointer persists opaque progress markers, such as the cursor of an
+// external feed, so that a worker can resume where it left off after a restart.
+// Checkpoints are keyed so that a single checkpointer can be shared by several
+// workers. A checkpointer registered in the service container under the name
+// "checkpointer" is injected into workers and their specs.
+type Checkpointer interface {
+	// Load returns the checkpoint saved under the given key. If no checkpoint
+	// has been saved, false is returned.
+	Load(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Save replaces the checkpoint saved under the given key.
+	Save(ctx context.Context, key string, checkpoint []byte) error
+}
+
+// CheckpointedSpec is an optional interface for a worker spec whose progress is
+// checkpointed by the worker. If the worker has a checkpointer, the checkpoint
+// saved under the worker's name is restored after the spec is initialized, and
+// the spec's checkpoint is saved after each successful tick. A failure to save
+// the checkpoint fails the tick. The worker must be named (see WithName). Each
+// spec constructed by a worker factory (see NewWorkerFromFactory) is keyed by
+// the worker's name and the spec's index, such as "feed/0".
+type CheckpointedSpec interface {
+	WorkerSpec
+
+	// RestoreCheckpoint is called with the most recently saved checkpoint.
+	// It is not called if no checkpoint has been saved.
+	RestoreCheckpoint(checkpoint []byte) error
+
+	// Checkpoint returns the checkpoint to save.
+	Checkpoint() ([]byte, error)
+}
+
+// MemoryCheckpointer is a Checkpointer held in memory. Its checkpoints are lost
+// when the process exits.
+type MemoryCheckpointer struct {
+	mutex       sync.Mutex
+	checkpoints map[string][]byte
+}
+
+var _ Checkpointer = &MemoryCheckpointer{}
+
+// NewMemoryCheckpointer creates an empty MemoryCheckpointer.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{checkpoints: map[string][]byte{}}
+}
+
+func (c *MemoryCheckpointer) Load(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	checkpoint, ok := c.checkpoints[key]
+	return append([]byte(nil), checkpoint...), ok, nil
+}
+
+func (c *MemoryCheckpointer) Save(ctx context.Context, key string, checkpoint []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.checkpoints[key] = append([]byte(nil), checkpoint...)
+	return nil
+}
+
+// FileCheckpointer is a Checkpointer persisted to a single file, which is
+// atomically rewritten on every save. A file must not be shared by more than
+// one FileCheckpointer.
+type FileCheckpointer struct {
+	path        string
+	mutex       sync.Mutex
+	checkpoints map[string][]byte
+}
+
+var _ Checkpointer = &FileCheckpointer{}
+
+// NewFileCheckpointer creates a FileCheckpointer persisted at the given path,
+// loading any checkpoints previously persisted there.
+func NewFileCheckpointer(path string) (*FileCheckpointer, error) {
+	checkpoints := map[string][]byte{}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(contents, &checkpoints); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FileCheckpointer{
+		path:        path,
+		checkpoints: checkpoints,
+	}, nil
+}
+
+func (c *FileCheckpointer) Load(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	checkpoint, ok := c.checkpoints[key]
+	return append([]byte(nil), checkpoint...), ok, nil
+}
+
+func (c *FileCheckpointer) Save(ctx context.Context, key string, checkpoint []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	checkpoints := make(map[string][]byte, len(c.checkpoints)+1)
+	for k, v := range c.checkpoints {
+		checkpoints[k] = v
+	}
+	checkpoints[key] = append([]byte(nil), checkpoint...)
+
+	contents, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+
+	if err := atomicfile.WriteFile(c.path, contents, 0o644); err != nil {
+		return err
+	}
+
+	c.checkpoints = checkpoints
+	return nil
+}
+
+// checkpointKey returns the key under which the checkpoint of the spec with the
+// given index is saved. The key is the worker's name, suffixed with the index of
+// the spec for a worker that constructs a spec per goroutine.
+func (w *Worker) checkpointKey(index int) string {
+	if w.factory == nil {
+		return w.name
+	}
+
+	return fmt.Sprintf("%s/%d", w.name, index)
+}
+
+// restoreCheckpoint restores the checkpoint of the spec with the given index, if
+// the worker has a checkpointer and the spec is checkpointed. An error is returned
+// if the worker has no name to key its checkpoints by.
+func (w *Worker) restoreCheckpoint(ctx context.Context, index int) error {
+	checkpointed, ok := w.specs[index].(CheckpointedSpec)
+	if !ok || w.Checkpointer == nil {
+		return nil
+	}
+
+	if w.name == "" {
+		return fmt.Errorf("checkpointed worker spec requires a worker name (see WithName)")
+	}
+
+	checkpoint, ok, err := w.Checkpointer.Load(ctx, w.checkpointKey(index))
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	return checkpointed.RestoreCheckpoint(checkpoint)
+}
+
+// saveCheckpoint saves the checkpoint of the spec with the given index, if the
+// worker has a checkpointer and the spec is checkpointed.
+func (w *Worker) saveCheckpoint(ctx context.Context, index int) error {
+	checkpointed, ok := w.specs[index].(CheckpointedSpec)
+	if !ok || w.Checkpointer == nil {
+		return nil
+	}
+
+	checkpoint, err := checkpointed.Checkpoint()
+	if err != nil {
+		return err
+	}
+
+	if err := w.Checkpointer.Save(ctx, w.checkpointKey(index), checkpoint); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package workerbase
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/nacelle/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointers(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testCheckpointer(t, NewMemoryCheckpointer())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		checkpointer, err := NewFileCheckpointer(filepath.Join(t.TempDir(), "checkpoints.json"))
+		require.Nil(t, err)
+		testCheckpointer(t, checkpointer)
+	})
+}
+
+func testCheckpointer(t *testing.T, checkpointer Checkpointer) {
+	ctx := context.Background()
+
+	_, ok, err := checkpointer.Load(ctx, "a")
+	require.Nil(t, err)
+	assert.False(t, ok)
+
+	require.Nil(t, checkpointer.Save(ctx, "a", []byte("1")))
+	require.Nil(t, checkpointer.Save(ctx, "b", []byte("2")))
+	require.Nil(t, checkpointer.Save(ctx, "a", []byte("3")))
+
+	checkpoint, ok, err := checkpointer.Load(ctx, "a")
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("3"), checkpoint)
+
+	checkpoint, ok, err = checkpointer.Load(ctx, "b")
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("2"), checkpoint)
+}
+
+func TestFileCheckpointerPersistence(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "checkpoints.json")
+		ctx  = context.Background()
+	)
+
+	checkpointer, err := NewFileCheckpointer(path)
+	require.Nil(t, err)
+	require.Nil(t, checkpointer.Save(ctx, "a", []byte("cursor")))
+
+	// Reopen the checkpointer as if the process had restarted
+	checkpointer, err = NewFileCheckpointer(path)
+	require.Nil(t, err)
+
+	checkpoint, ok, err := checkpointer.Load(ctx, "a")
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("cursor"), checkpoint)
+}
+
+func TestCheckpoint(t *testing.T) {
+	var (
+		clock        = glock.NewMockClock()
+		checkpointer = NewMemoryCheckpointer()
+		spec         = &cursorWorkerSpec{}
+		worker       = makeWorker(spec, clock, WithName("feed"))
+		errChan      = make(chan error)
+	)
+
+	ctx := context.Background()
+	require.Nil(t, checkpointer.Save(ctx, "feed", []byte("5")))
+	worker.Services.Set("checkpointer", checkpointer)
+	worker.Checkpointer = checkpointer
+	worker.Config = testConfig
+
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, 5, spec.getCursor())
+
+	// The checkpointer is also injected into the spec
+	assert.Same(t, checkpointer, spec.Checkpointer)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return spec.getCursor() == 6 })
+	clock.BlockingAdvance(time.Second * 5)
+	eventually(t, func() bool { return spec.getCursor() == 7 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	checkpoint, ok, err := checkpointer.Load(ctx, "feed")
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("7"), checkpoint)
+}
+
+func TestCheckpointSaveError(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		spec    = &cursorWorkerSpec{}
+		worker  = makeWorker(spec, clock, WithName("feed"))
+		errChan = make(chan error)
+	)
+
+	worker.Services.Set("checkpointer", NewMemoryCheckpointer())
+	worker.Checkpointer = &failingCheckpointer{}
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "failed to save checkpoint: oops")
+}
+
+func TestCheckpointRequiresName(t *testing.T) {
+	var (
+		clock  = glock.NewMockClock()
+		spec   = &cursorWorkerSpec{}
+		worker = makeWorker(spec, clock)
+	)
+
+	worker.Services.Set("checkpointer", NewMemoryCheckpointer())
+	worker.Checkpointer = NewMemoryCheckpointer()
+	worker.Config = testConfig
+
+	err := worker.Init(context.Background())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "requires a worker name")
+}
+
+func TestCheckpointFactory(t *testing.T) {
+	var (
+		clock        = glock.NewMockClock()
+		checkpointer = NewMemoryCheckpointer()
+		specs        []*cursorWorkerSpec
+		errChan      = make(chan error)
+	)
+
+	factory := func() WorkerSpec {
+		spec := &cursorWorkerSpec{}
+		specs = append(specs, spec)
+		return spec
+	}
+
+	worker := newWorker(nil, factory, clock, WithName("feed"))
+	worker.Services = nacelle.NewServiceContainer()
+	worker.Health = nacelle.NewHealth()
+	worker.Services.Set("checkpointer", checkpointer)
+	worker.Checkpointer = checkpointer
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "5",
+		"worker_concurrency":   "2",
+	}))
+
+	ctx := context.Background()
+	require.Nil(t, checkpointer.Save(ctx, "feed/0", []byte("10")))
+	require.Nil(t, checkpointer.Save(ctx, "feed/1", []byte("20")))
+
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, 10, specs[0].getCursor())
+	assert.Equal(t, 20, specs[1].getCursor())
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return specs[0].getCursor() == 11 && specs[1].getCursor() == 21 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	// Each spec saves its own checkpoint
+	for key, expected := range map[string]string{"feed/0": "11", "feed/1": "21"} {
+		checkpoint, ok, err := checkpointer.Load(ctx, key)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte(expected), checkpoint)
+	}
+}
+
+type cursorWorkerSpec struct {
+	Checkpointer Checkpointer `service:"checkpointer"`
+	mutex        sync.Mutex
+	cursor       int
+}
+
+func (s *cursorWorkerSpec) Init(ctx context.Context) error { return nil }
+
+func (s *cursorWorkerSpec) Tick(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.cursor++
+	return nil
+}
+
+func (s *cursorWorkerSpec) RestoreCheckpoint(checkpoint []byte) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.cursor, err = strconv.Atoi(string(checkpoint))
+	return err
+}
+
+func (s *cursorWorkerSpec) Checkpoint() ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return []byte(strconv.Itoa(s.cursor)), nil
+}
+
+func (s *cursorWorkerSpec) getCursor() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.cursor
+}
+
+type failingCheckpointer struct{}
+
+func (c *failingCheckpointer) Load(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (c *failingCheckpointer) Save(ctx context.Context, key string, checkpoint []byte) error {
+	return errors.New("oops")
+}
@@ -1,15 +1,174 @@
 package workerbase
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policies controlling how the failure of one concurrent tick loop affects
+// the remaining tick loops.
+const (
+	ErrorPolicyCancel  = "cancel"
+	ErrorPolicyIsolate = "isolate"
+)
+
+// Policies controlling how a tick that exceeds the tick timeout is handled.
+const (
+	TimeoutPolicyFatal = "fatal"
+	TimeoutPolicyRetry = "retry"
+	TimeoutPolicySkip  = "skip"
+)
+
+// Policies controlling how a panic within a tick is handled.
+const (
+	PanicPolicyFatal    = "fatal"
+	PanicPolicyContinue = "continue"
+)
+
+// Policies controlling how a fixed-rate worker handles slots missed while a
+// tick overran its interval.
+const (
+	MissedTickPolicySkip    = "skip"
+	MissedTickPolicyRunOnce = "run-once"
+	MissedTickPolicyRunAll  = "run-all"
+)
 
 type Config struct {
-	StrictClock           bool `env:"worker_strict_clock"`
-	RawWorkerTickInterval int  `env:"worker_tick_interval" default:"0"`
+	StrictClock               bool    `env:"worker_strict_clock"`
+	FixedRate                 bool    `env:"worker_fixed_rate"`
+	MissedTickPolicy          string  `env:"worker_missed_tick_policy" default:"skip"`
+	RawWorkerTickInterval     string  `env:"worker_tick_interval" default:"0"`
+	RawTickJitter             string  `env:"worker_tick_jitter" default:"0"`
+	RawInitialJitter          string  `env:"worker_initial_jitter" default:"0"`
+	RawInitialDelay           string  `env:"worker_initial_delay" default:"0"`
+	TickOnStart               bool    `env:"worker_tick_on_start" default:"true"`
+	Schedule                  string  `env:"worker_schedule"`
+	ScheduleTimezone          string  `env:"worker_schedule_timezone" default:"Local"`
+	RetryEnabled              bool    `env:"worker_retry_enabled"`
+	RawRetryInitialDelay      string  `env:"worker_retry_initial_delay" default:"1s"`
+	RetryMultiplier           float64 `env:"worker_retry_multiplier" default:"2"`
+	RawRetryMaxDelay          string  `env:"worker_retry_max_delay" default:"1m"`
+	RetryMaxAttempts          int     `env:"worker_retry_max_attempts" default:"0"`
+	RetryJitter               string  `env:"worker_retry_jitter" default:"none"`
+	Concurrency               int     `env:"worker_concurrency" default:"1"`
+	ConcurrencyErrorPolicy    string  `env:"worker_concurrency_error_policy" default:"cancel"`
+	RawTickTimeout            string  `env:"worker_tick_timeout" default:"0"`
+	TickTimeoutPolicy         string  `env:"worker_tick_timeout_policy" default:"fatal"`
+	RawDrainPeriod            string  `env:"worker_drain_period" default:"0"`
+	TriggerResetsInterval     bool    `env:"worker_trigger_resets_interval"`
+	UnhealthyFailureThreshold int     `env:"worker_unhealthy_failure_threshold" default:"0"`
+	RawUnhealthyStaleness     string  `env:"worker_unhealthy_staleness" default:"0"`
+	PanicPolicy               string  `env:"worker_panic_policy" default:"fatal"`
 
 	WorkerTickInterval time.Duration
+	TickJitter         time.Duration
+	TickJitterFraction float64
+	InitialJitter      time.Duration
+	InitialDelay       time.Duration
+	RetryInitialDelay  time.Duration
+	RetryMaxDelay      time.Duration
+	TickTimeout        time.Duration
+	DrainPeriod        time.Duration
+	UnhealthyStaleness time.Duration
 }
 
 func (c *Config) PostLoad() error {
-	c.WorkerTickInterval = time.Duration(c.RawWorkerTickInterval) * time.Second
+	var err error
+	if c.WorkerTickInterval, err = parseDuration(c.RawWorkerTickInterval); err != nil {
+		return fmt.Errorf("invalid tick interval: %w", err)
+	}
+	if c.TickJitter, c.TickJitterFraction, err = parseJitter(c.RawTickJitter); err != nil {
+		return fmt.Errorf("invalid tick jitter: %w", err)
+	}
+	if c.InitialJitter, err = parseDuration(c.RawInitialJitter); err != nil {
+		return fmt.Errorf("invalid initial jitter: %w", err)
+	}
+	if c.InitialDelay, err = parseDuration(c.RawInitialDelay); err != nil {
+		return fmt.Errorf("invalid initial delay: %w", err)
+	}
+	switch c.MissedTickPolicy {
+	case MissedTickPolicySkip, MissedTickPolicyRunOnce, MissedTickPolicyRunAll:
+	default:
+		return fmt.Errorf("unknown missed tick policy %q", c.MissedTickPolicy)
+	}
+
+	if c.RetryInitialDelay, err = parseDuration(c.RawRetryInitialDelay); err != nil {
+		return fmt.Errorf("invalid retry initial delay: %w", err)
+	}
+	if c.RetryMaxDelay, err = parseDuration(c.RawRetryMaxDelay); err != nil {
+		return fmt.Errorf("invalid retry max delay: %w", err)
+	}
+	if c.RetryMultiplier < 1 {
+		return fmt.Errorf("retry multiplier must be at least 1")
+	}
+
+	if err := validateJitter(c.RetryJitter); err != nil {
+		return err
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	if c.ConcurrencyErrorPolicy != ErrorPolicyCancel && c.ConcurrencyErrorPolicy != ErrorPolicyIsolate {
+		return fmt.Errorf("unknown concurrency error policy %q", c.ConcurrencyErrorPolicy)
+	}
+
+	if c.TickTimeout, err = parseDuration(c.RawTickTimeout); err != nil {
+		return fmt.Errorf("invalid tick timeout: %w", err)
+	}
+	switch c.TickTimeoutPolicy {
+	case TimeoutPolicyFatal, TimeoutPolicyRetry, TimeoutPolicySkip:
+	default:
+		return fmt.Errorf("unknown tick timeout policy %q", c.TickTimeoutPolicy)
+	}
+
+	if c.PanicPolicy != PanicPolicyFatal && c.PanicPolicy != PanicPolicyContinue {
+		return fmt.Errorf("unknown panic policy %q", c.PanicPolicy)
+	}
+
+	if c.DrainPeriod, err = parseDuration(c.RawDrainPeriod); err != nil {
+		return fmt.Errorf("invalid drain period: %w", err)
+	}
+	if c.UnhealthyStaleness, err = parseDuration(c.RawUnhealthyStaleness); err != nil {
+		return fmt.Errorf("invalid unhealthy staleness: %w", err)
+	}
+
 	return nil
 }
+
+// parseDuration parses a duration string such as "1m30s". A bare integer is
+// interpreted as a number of seconds. Negative durations are rejected.
+func parseDuration(value string) (time.Duration, error) {
+	var duration time.Duration
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		duration = time.Duration(seconds) * time.Second
+	} else if duration, err = time.ParseDuration(value); err != nil {
+		return 0, err
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("duration %q must not be negative", value)
+	}
+
+	return duration, nil
+}
+
+// parseJitter parses a tick jitter, which is either a fraction of the tick
+// interval written as a decimal number (e.g. "0.1") or a duration accepted
+// by parseDuration.
+func parseJitter(value string) (time.Duration, float64, error) {
+	if strings.Contains(value, ".") {
+		if fraction, err := strconv.ParseFloat(value, 64); err == nil {
+			if fraction < 0 || fraction > 1 {
+				return 0, 0, fmt.Errorf("fraction %q must be between 0 and 1", value)
+			}
+
+			return 0, fraction, nil
+		}
+	}
+
+	duration, err := parseDuration(value)
+	return duration, 0, err
+}
@@ -0,0 +1,35 @@
+package workerbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "0", expected: 0},
+		{value: "30", expected: time.Second * 30},
+		{value: "500ms", expected: time.Millisecond * 500},
+		{value: "1m30s", expected: time.Second * 90},
+		{value: "2h", expected: time.Hour * 2},
+	}
+
+	for _, testCase := range testCases {
+		duration, err := parseDuration(testCase.value)
+		require.Nil(t, err)
+		assert.Equal(t, testCase.expected, duration)
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	for _, value := range []string{"", "soon", "1.5", "10 minutes", "-5", "-1m"} {
+		_, err := parseDuration(value)
+		assert.NotNil(t, err, "value %q", value)
+	}
+}
@@ -0,0 +1,61 @@
+package workerbase
+
+import (
+	"context"
+	"time"
+)
+
+type tickInfoKeyType struct{}
+
+var tickInfoKey = tickInfoKeyType{}
+
+type tickInfo struct {
+	workerName    string
+	tickNumber    int64
+	scheduledTime time.Time
+}
+
+func contextWithTickInfo(ctx context.Context, info tickInfo) context.Context {
+	return context.WithValue(ctx, tickInfoKey, info)
+}
+
+func tickInfoFromContext(ctx context.Context) tickInfo {
+	if v, ok := ctx.Value(tickInfoKey).(tickInfo); ok {
+		return v
+	}
+	return tickInfo{}
+}
+
+// WorkerNameFromContext returns the name of the worker invoking the current
+// tick (see WithName). An empty string is returned outside of a tick.
+func WorkerNameFromContext(ctx context.Context) string {
+	return tickInfoFromContext(ctx).workerName
+}
+
+// TickNumberFromContext returns the one-based sequence number of the current
+// tick within the worker. Zero is returned outside of a tick.
+func TickNumberFromContext(ctx context.Context) int64 {
+	return tickInfoFromContext(ctx).tickNumber
+}
+
+// ScheduledTimeFromContext returns the time at which the current tick was
+// scheduled to begin. This may be earlier than the time the tick actually
+// began. A zero time is returned outside of a tick.
+func ScheduledTimeFromContext(ctx context.Context) time.Time {
+	return tickInfoFromContext(ctx).scheduledTime
+}
+
+// detachedContext is a context that exposes the values of its parent but
+// is never cancelled and has no deadline.
+type detachedContext struct {
+	parent context.Context
+}
+
+func detachContext(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func (ctx detachedContext) Deadline() (time.Time, bool)       { return time.Time{}, false }
+func (ctx detachedContext) Done() <-chan struct{}             { return nil }
+func (ctx detachedContext) Err() error                        { return nil }
+func (ctx detachedContext) Value(key interface{}) interface{} { return ctx.parent.Value(key) }
@@ -0,0 +1,115 @@
+package workerbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TickTimeoutError is returned when a tick does not complete within the
+// configured tick timeout.
+type TickTimeoutError struct {
+	// Timeout is the configured tick timeout.
+	Timeout time.Duration
+
+	// Err is the error returned by the tick, if any.
+	Err error
+}
+
+func (e *TickTimeoutError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("tick exceeded timeout of %s", e.Timeout)
+	}
+
+	return fmt.Sprintf("tick exceeded timeout of %s: %s", e.Timeout, e.Err)
+}
+
+// Is reports whether the target is context.DeadlineExceeded, so that a timeout
+// can be recognized regardless of the error returned by the tick.
+func (e *TickTimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// Unwrap returns the error returned by the tick, if any.
+func (e *TickTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is returned when a tick panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("tick panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+
+	return nil
+}
+
+// RestartIntensityError is returned by a Supervisor whose children were restarted
+// more than MaxRestarts times within Period.
+type RestartIntensityError struct {
+	MaxRestarts int
+	Period      time.Duration
+
+	// Err is the error of the child failure that exceeded the intensity.
+	Err error
+}
+
+func (e *RestartIntensityError) Error() string {
+	return fmt.Sprintf("more than %d restarts within %s: %s", e.MaxRestarts, e.Period, e.Err)
+}
+
+func (e *RestartIntensityError) Unwrap() error {
+	return e.Err
+}
+
+func isTickTimeout(err error) bool {
+	var timeoutErr *TickTimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+// multiError is an error that aggregates the errors of several tick loops.
+type multiError struct {
+	errs []error
+}
+
+// newMultiError returns an error aggregating the given errors. This function
+// returns nil if the given list is empty and returns a singleton error as-is.
+func newMultiError(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	return &multiError{errs: errs}
+}
+
+func (e *multiError) Error() string {
+	messages := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the aggregated errors.
+func (e *multiError) Unwrap() []error {
+	return e.errs
+}
@@ -0,0 +1,388 @@
+package workerbase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/config/v3"
+	"github.com/go-nacelle/nacelle/v2"
+)
+
+// Policies controlling how a WorkerGroup responds to a member that fails.
+const (
+	GroupPolicyStopAll = "stop-all"
+	GroupPolicyRestart = "restart"
+	GroupPolicyIgnore  = "ignore"
+)
+
+// WorkerGroup runs a set of named worker specs as a single process. Each member
+// is a Worker configured with its own options, and reports its own health.
+type WorkerGroup struct {
+	Config        *nacelle.Config           `service:"config"`
+	Services      *nacelle.ServiceContainer `service:"services"`
+	Health        *nacelle.Health           `service:"health"`
+	Logger        nacelle.Logger            `service:"logger" optional:"true"`
+	Checkpointer  Checkpointer              `service:"checkpointer" optional:"true"`
+	tagModifiers  []config.TagModifier
+	clock         glock.Clock
+	members       []*groupMember
+	failurePolicy string
+	restartDelay  time.Duration
+	halt          chan struct{}
+	done          chan struct{}
+	once          *sync.Once
+
+	// The following fields are protected by mu
+	mu      sync.Mutex
+	running bool
+}
+
+type groupMember struct {
+	name    string
+	spec    WorkerSpec
+	configs []ConfigFunc
+
+	// The following fields are protected by the group's mu
+	worker   *Worker
+	restarts int
+	err      error
+}
+
+// MemberStatus describes the current status of a member of a WorkerGroup.
+type MemberStatus struct {
+	// Name is the name with which the member was added to the group.
+	Name string
+
+	// State is the state of the member's current worker.
+	State WorkerState
+
+	// Healthy is true if the member's worker currently reports itself as healthy.
+	Healthy bool
+
+	// Restarts is the number of times the member has been restarted.
+	Restarts int
+
+	// Err is the error with which the member's worker most recently failed.
+	Err error
+}
+
+// GroupConfig is the configuration loaded by a WorkerGroup on initialization.
+type GroupConfig struct {
+	FailurePolicy   string `env:"worker_group_failure_policy" default:"stop-all"`
+	RawRestartDelay string `env:"worker_group_restart_delay" default:"1s"`
+
+	RestartDelay time.Duration
+}
+
+func (c *GroupConfig) PostLoad() error {
+	switch c.FailurePolicy {
+	case GroupPolicyStopAll, GroupPolicyRestart, GroupPolicyIgnore:
+	default:
+		return fmt.Errorf("unknown group failure policy %q", c.FailurePolicy)
+	}
+
+	var err error
+	if c.RestartDelay, err = parseDuration(c.RawRestartDelay); err != nil {
+		return fmt.Errorf("invalid group restart delay: %w", err)
+	}
+
+	return nil
+}
+
+// GroupConfigFunc is a function used to configure an instance of a WorkerGroup.
+type GroupConfigFunc func(*WorkerGroup)
+
+// WithGroupTagModifiers applies the given tag modifiers when loading the group's
+// configuration. These modifiers do not apply to the configuration of members.
+func WithGroupTagModifiers(modifiers ...config.TagModifier) GroupConfigFunc {
+	return func(g *WorkerGroup) { g.tagModifiers = append(g.tagModifiers, modifiers...) }
+}
+
+// NewWorkerGroup creates an empty WorkerGroup. Members are added with Add.
+func NewWorkerGroup(configs ...GroupConfigFunc) *WorkerGroup {
+	return newWorkerGroup(glock.NewRealClock(), configs...)
+}
+
+func newWorkerGroup(clock glock.Clock, configs ...GroupConfigFunc) *WorkerGroup {
+	g := &WorkerGroup{
+		clock: clock,
+		halt:  make(chan struct{}),
+		done:  make(chan struct{}),
+		once:  &sync.Once{},
+	}
+
+	for _, f := range configs {
+		f(g)
+	}
+
+	return g
+}
+
+// Add registers a spec to be run by the group under the given name. The name is
+// supplied to the member's worker via WithName, and the given options apply to
+// that worker only. Members must be added before the group is initialized.
+func (g *WorkerGroup) Add(name string, spec WorkerSpec, configs ...ConfigFunc) {
+	g.members = append(g.members, &groupMember{
+		name:    name,
+		spec:    spec,
+		configs: append([]ConfigFunc{WithName(name)}, configs...),
+	})
+}
+
+func (g *WorkerGroup) Init(ctx context.Context) error {
+	if g.Logger == nil {
+		g.Logger = nacelle.NewNilLogger()
+	}
+
+	groupConfig := &GroupConfig{}
+	if err := g.Config.Load(groupConfig, g.tagModifiers...); err != nil {
+		return err
+	}
+
+	g.failurePolicy = groupConfig.FailurePolicy
+	g.restartDelay = groupConfig.RestartDelay
+
+	names := map[string]struct{}{}
+	for _, member := range g.members {
+		if _, ok := names[member.name]; ok {
+			return fmt.Errorf("duplicate worker group member %q", member.name)
+		}
+		names[member.name] = struct{}{}
+
+		worker := newWorker(member.spec, nil, g.clock, member.configs...)
+		worker.Config = g.Config
+		worker.Services = g.Services
+		worker.Health = g.Health
+		worker.Logger = g.Logger
+		worker.Checkpointer = g.Checkpointer
+
+		if err := worker.Init(ctx); err != nil {
+			return fmt.Errorf("failed to initialize worker %q: %w", member.name, err)
+		}
+
+		member.worker = worker
+	}
+
+	return nil
+}
+
+// Run runs every member of the group until the group is stopped. If the group's
+// failure policy is stop-all, the failure of any member stops the remaining
+// members and the errors of the failed members are returned.
+func (g *WorkerGroup) Run(ctx context.Context) error {
+	defer g.Stop(ctx)
+	defer close(g.done)
+
+	g.mu.Lock()
+	if g.halted() {
+		// Stopped before running, so no members are started and the specs
+		// initialized by Init are finalized here
+		g.mu.Unlock()
+		return g.finalizeMembers(ctx)
+	}
+	g.running = true
+	g.mu.Unlock()
+
+	errs := make(chan error, len(g.members))
+	var wg sync.WaitGroup
+
+	for _, member := range g.members {
+		wg.Add(1)
+
+		go func(member *groupMember) {
+			defer wg.Done()
+
+			if err := g.runMember(ctx, member); err != nil {
+				errs <- err
+				g.signalHalt()
+				g.stopMembers(ctx)
+			}
+		}(member)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var memberErrs []error
+	for err := range errs {
+		memberErrs = append(memberErrs, err)
+	}
+
+	return newMultiError(memberErrs)
+}
+
+// finalizeMembers finalizes the specs of each member's worker. The first error
+// is returned.
+func (g *WorkerGroup) finalizeMembers(ctx context.Context) (err error) {
+	for _, member := range g.members {
+		if finalizeErr := member.worker.finalize(ctx); err == nil {
+			err = finalizeErr
+		}
+	}
+
+	return err
+}
+
+// runMember runs the given member's worker until the group is halted, restarting
+// it according to the group's failure policy. An error is returned only if the
+// member's failure should stop the group.
+func (g *WorkerGroup) runMember(ctx context.Context, member *groupMember) error {
+	for {
+		g.mu.Lock()
+		worker := member.worker
+		g.mu.Unlock()
+
+		err := worker.Run(ctx)
+		if err == nil || g.halted() {
+			return nil
+		}
+
+		g.mu.Lock()
+		member.err = err
+		g.mu.Unlock()
+
+		switch g.failurePolicy {
+		case GroupPolicyStopAll:
+			g.Logger.Error("Worker %q failed, stopping worker group (%s)", member.name, err)
+			return fmt.Errorf("worker %q: %w", member.name, err)
+
+		case GroupPolicyIgnore:
+			g.Logger.Error("Worker %q failed (%s)", member.name, err)
+			return nil
+		}
+
+		g.Logger.Error("Worker %q failed, restarting in %s (%s)", member.name, g.restartDelay, err)
+
+		select {
+		case <-g.halt:
+			return nil
+		case <-g.clock.After(g.restartDelay):
+		}
+
+		if !g.restartMember(ctx, member) {
+			return nil
+		}
+	}
+}
+
+// restartMember replaces the given member's stopped worker with a newly
+// initialized one. This method returns false if the group was halted or the
+// new worker could not be initialized.
+func (g *WorkerGroup) restartMember(ctx context.Context, member *groupMember) bool {
+	g.mu.Lock()
+	worker := member.worker.restart(true)
+	g.mu.Unlock()
+
+	if err := worker.Init(ctx); err != nil {
+		g.Logger.Error("Failed to reinitialize worker %q (%s)", member.name, err)
+
+		g.mu.Lock()
+		member.err = err
+		g.mu.Unlock()
+		return false
+	}
+
+	g.mu.Lock()
+	// Do not start a worker that a concurrent Stop would not see
+	if g.halted() {
+		g.mu.Unlock()
+
+		// The reinitialized worker is never run, so its specs are finalized here
+		if err := worker.finalize(ctx); err != nil {
+			g.Logger.Error("Failed to finalize worker %q (%s)", member.name, err)
+		}
+
+		return false
+	}
+
+	member.worker = worker
+	member.restarts++
+	g.mu.Unlock()
+	return true
+}
+
+// Members returns the current status of each member of the group, in the order
+// in which the members were added.
+func (g *WorkerGroup) Members() []MemberStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	statuses := make([]MemberStatus, 0, len(g.members))
+	for _, member := range g.members {
+		status := MemberStatus{
+			Name:     member.name,
+			Restarts: member.restarts,
+			Err:      member.err,
+		}
+		if member.worker != nil {
+			status.State = member.worker.State()
+			status.Healthy = member.worker.healthStatus != nil && member.worker.healthStatus.Healthy()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// Stop instructs every member of the group to stop and blocks until they do.
+// If the given context is done before Run exits, its error is returned. Calling
+// Stop on a group that has not started running is a no-op.
+func (g *WorkerGroup) Stop(ctx context.Context) error {
+	g.signalHalt()
+
+	g.mu.Lock()
+	running := g.running
+	g.mu.Unlock()
+
+	if !running {
+		return nil
+	}
+
+	if err := g.stopMembers(ctx); err != nil {
+		return err
+	}
+
+	select {
+	case <-g.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stopMembers stops the current worker of each member.
+func (g *WorkerGroup) stopMembers(ctx context.Context) error {
+	g.mu.Lock()
+	workers := make([]*Worker, 0, len(g.members))
+	for _, member := range g.members {
+		workers = append(workers, member.worker)
+	}
+	g.mu.Unlock()
+
+	var errs []error
+	for _, worker := range workers {
+		if err := worker.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return newMultiError(errs)
+}
+
+// halted returns true if the group has been instructed to stop.
+func (g *WorkerGroup) halted() bool {
+	select {
+	case <-g.halt:
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *WorkerGroup) signalHalt() {
+	g.once.Do(func() { close(g.halt) })
+}
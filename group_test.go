@@ -0,0 +1,316 @@
+package workerbase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/go-nacelle/nacelle/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupRunAndStop(t *testing.T) {
+	var (
+		spec1   = NewMockWorkerSpecFinalizer()
+		spec2   = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		group   = makeWorkerGroup(clock)
+		errChan = make(chan error)
+	)
+
+	group.Add("a", spec1)
+	group.Add("b", spec2, WithTagModifiers(nacelle.NewEnvTagPrefixer("b")))
+	group.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":   "5",
+		"b_worker_tick_interval": "10",
+	}))
+
+	ctx := context.Background()
+	err := group.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- group.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec1.TickFunc.History()) == 1 && len(spec2.TickFunc.History()) == 1 })
+	eventually(t, func() bool { return clock.BlockedOnAfter() == 2 })
+	clock.Advance(time.Second * 5)
+	eventually(t, func() bool { return len(spec1.TickFunc.History()) == 2 })
+	consistently(t, func() bool { return len(spec2.TickFunc.History()) == 1 })
+
+	assert.Equal(t, []MemberStatus{
+		{Name: "a", State: StateRunning, Healthy: true},
+		{Name: "b", State: StateRunning, Healthy: true},
+	}, group.Members())
+
+	err = group.Stop(ctx)
+	require.Nil(t, err)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.CalledOnce(t, spec1.FinalizeFunc)
+	mockassert.CalledOnce(t, spec2.FinalizeFunc)
+
+	assert.Equal(t, []MemberStatus{
+		{Name: "a", State: StateStopped, Healthy: true},
+		{Name: "b", State: StateStopped, Healthy: true},
+	}, group.Members())
+}
+
+func TestGroupStopAll(t *testing.T) {
+	var (
+		spec1   = NewMockWorkerSpecFinalizer()
+		spec2   = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		group   = makeWorkerGroup(clock)
+		errChan = make(chan error)
+	)
+
+	spec1.TickFunc.PushHook(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	spec2.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	group.Add("a", spec1)
+	group.Add("b", spec2)
+	group.Config = testConfig
+
+	ctx := context.Background()
+	err := group.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- group.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, `worker "b": oops`)
+	mockassert.CalledOnce(t, spec1.FinalizeFunc)
+	mockassert.CalledOnce(t, spec2.FinalizeFunc)
+
+	members := group.Members()
+	assert.Equal(t, StateStopped, members[0].State)
+	assert.Nil(t, members[0].Err)
+	assert.Equal(t, StateStopped, members[1].State)
+	assert.EqualError(t, members[1].Err, "oops")
+}
+
+func TestGroupRestart(t *testing.T) {
+	var (
+		spec1   = NewMockWorkerSpecFinalizer()
+		spec2   = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		group   = makeWorkerGroup(clock)
+		errChan = make(chan error)
+	)
+
+	spec2.TickFunc.PushHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	group.Add("a", spec1)
+	group.Add("b", spec2)
+	group.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":        "60",
+		"worker_group_failure_policy": "restart",
+		"worker_group_restart_delay":  "10s",
+	}))
+
+	ctx := context.Background()
+	err := group.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- group.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec2.TickFunc.History()) == 1 })
+	eventually(t, func() bool { return clock.BlockedOnAfter() == 2 })
+	clock.Advance(time.Second * 10)
+
+	// Restarted member is reinitialized and ticks again
+	eventually(t, func() bool { return len(spec2.TickFunc.History()) == 2 })
+	mockassert.CalledN(t, spec2.InitFunc, 2)
+	mockassert.CalledOnce(t, spec2.FinalizeFunc)
+	mockassert.CalledOnce(t, spec1.TickFunc)
+
+	members := group.Members()
+	assert.Equal(t, 1, members[1].Restarts)
+	assert.EqualError(t, members[1].Err, "oops")
+	assert.Equal(t, StateRunning, members[1].State)
+
+	err = group.Stop(ctx)
+	require.Nil(t, err)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.CalledN(t, spec2.FinalizeFunc, 2)
+}
+
+func TestGroupStopDuringRestart(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		group   = makeWorkerGroup(clock)
+		errChan = make(chan error)
+	)
+
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+	spec.InitFunc.PushHook(func(ctx context.Context) error {
+		return nil
+	})
+	spec.InitFunc.PushHook(func(ctx context.Context) error {
+		// The group is stopped while the failed member is reinitialized
+		group.signalHalt()
+		return nil
+	})
+
+	group.Add("a", spec)
+	group.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":        "60",
+		"worker_group_failure_policy": "restart",
+		"worker_group_restart_delay":  "10s",
+	}))
+
+	ctx := context.Background()
+	err := group.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- group.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return clock.BlockedOnAfter() == 1 })
+	clock.Advance(time.Second * 10)
+
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	// The reinitialized spec is finalized even though it never runs
+	mockassert.CalledOnce(t, spec.TickFunc)
+	mockassert.CalledN(t, spec.InitFunc, 2)
+	mockassert.CalledN(t, spec.FinalizeFunc, 2)
+}
+
+func TestGroupIgnore(t *testing.T) {
+	var (
+		spec1   = NewMockWorkerSpecFinalizer()
+		spec2   = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		group   = makeWorkerGroup(clock)
+		errChan = make(chan error)
+	)
+
+	spec2.TickFunc.PushHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	group.Add("a", spec1)
+	group.Add("b", spec2)
+	group.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":        "5",
+		"worker_group_failure_policy": "ignore",
+	}))
+
+	ctx := context.Background()
+	err := group.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- group.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return group.Members()[1].State == StateStopped })
+	clock.BlockingAdvance(time.Second * 5)
+	eventually(t, func() bool { return len(spec1.TickFunc.History()) == 2 })
+	mockassert.CalledOnce(t, spec2.TickFunc)
+
+	// The failed member is not restarted, so it remains unhealthy
+	members := group.Members()
+	assert.True(t, members[0].Healthy)
+	assert.False(t, members[1].Healthy)
+	assert.False(t, group.Health.Healthy())
+
+	err = group.Stop(ctx)
+	require.Nil(t, err)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestGroupInitErrors(t *testing.T) {
+	var (
+		spec1 = NewMockWorkerSpecFinalizer()
+		spec2 = NewMockWorkerSpecFinalizer()
+		clock = glock.NewMockClock()
+	)
+
+	spec2.InitFunc.SetDefaultReturn(fmt.Errorf("oops"))
+
+	group := makeWorkerGroup(clock)
+	group.Add("a", spec1)
+	group.Add("b", spec2)
+	group.Config = testConfig
+	err := group.Init(context.Background())
+	assert.EqualError(t, err, `failed to initialize worker "b": oops`)
+
+	group = makeWorkerGroup(clock)
+	group.Add("a", spec1)
+	group.Add("a", spec1)
+	group.Config = testConfig
+	err = group.Init(context.Background())
+	assert.EqualError(t, err, `duplicate worker group member "a"`)
+
+	group = makeWorkerGroup(clock)
+	group.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_group_failure_policy": "panic",
+	}))
+	err = group.Init(context.Background())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown group failure policy")
+}
+
+func TestGroupStopBeforeRun(t *testing.T) {
+	var (
+		spec1   = NewMockWorkerSpecFinalizer()
+		spec2   = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		group   = makeWorkerGroup(clock)
+		errChan = make(chan error)
+	)
+
+	group.Add("a", spec1)
+	group.Add("b", spec2)
+	group.Config = testConfig
+
+	ctx := context.Background()
+	err := group.Init(ctx)
+	require.Nil(t, err)
+	require.Nil(t, group.Stop(ctx))
+
+	go func() {
+		errChan <- group.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	for _, spec := range []*MockWorkerSpecFinalizer{spec1, spec2} {
+		mockassert.NotCalled(t, spec.TickFunc)
+		mockassert.CalledOnce(t, spec.FinalizeFunc)
+	}
+}
+
+func makeWorkerGroup(clock glock.Clock, configs ...GroupConfigFunc) *WorkerGroup {
+	group := newWorkerGroup(clock, configs...)
+	group.Services = nacelle.NewServiceContainer()
+	group.Health = nacelle.NewHealth()
+	return group
+}
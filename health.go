@@ -5,3 +5,71 @@ type healthToken string
 func (t healthToken) String() string {
 	return "worker-init"
 }
+
+// recordTickOutcome tracks consecutive tick failures and the time of the most
+// recent successful tick, and updates the worker's health status accordingly.
+func (w *Worker) recordTickOutcome(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err != nil {
+		w.consecutiveFailures++
+	} else {
+		w.consecutiveFailures = 0
+		w.lastSuccess = w.clock.Now()
+		w.stale = false
+
+		select {
+		case w.successSignal <- struct{}{}:
+		default:
+		}
+	}
+
+	w.updateHealthLocked()
+}
+
+// monitorStaleness marks the worker as unhealthy if no tick succeeds within the
+// configured staleness window. The worker is marked healthy again by the next
+// successful tick. This method blocks until the worker is halted.
+func (w *Worker) monitorStaleness() {
+	for {
+		w.mu.Lock()
+		deadline, stale := w.lastSuccess.Add(w.stalenessWindow), w.stale
+		w.mu.Unlock()
+
+		if stale {
+			select {
+			case <-w.halt:
+				return
+			case <-w.successSignal:
+			}
+
+			continue
+		}
+
+		select {
+		case <-w.halt:
+			return
+		case <-w.clock.After(deadline.Sub(w.clock.Now())):
+		}
+
+		w.mu.Lock()
+		if now := w.clock.Now(); !now.Before(w.lastSuccess.Add(w.stalenessWindow)) {
+			if w.paused {
+				// Paused workers are not expected to tick
+				w.lastSuccess = now
+			} else {
+				w.stale = true
+				w.updateHealthLocked()
+			}
+		}
+		w.mu.Unlock()
+	}
+}
+
+// updateHealthLocked updates the worker's health status from the current
+// failure count and staleness. Callers MUST lock w.mu.
+func (w *Worker) updateHealthLocked() {
+	healthy := !w.stale && (w.failureThreshold <= 0 || w.consecutiveFailures < w.failureThreshold)
+	w.healthStatus.Update(healthy)
+}
@@ -0,0 +1,120 @@
+package workerbase
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/nacelle/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthConsecutiveFailures(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+		failing int32
+	)
+
+	atomic.StoreInt32(&failing, 1)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		if atomic.LoadInt32(&failing) == 1 {
+			return fmt.Errorf("oops")
+		}
+
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":               "60",
+		"worker_retry_enabled":               "true",
+		"worker_retry_initial_delay":         "1s",
+		"worker_retry_multiplier":            "1",
+		"worker_unhealthy_failure_threshold": "3",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.TickFunc.History()) == 1 })
+	assert.True(t, worker.healthStatus.Healthy())
+	clock.BlockingAdvance(time.Second)
+	eventually(t, func() bool { return len(spec.TickFunc.History()) == 2 })
+	assert.True(t, worker.healthStatus.Healthy())
+	clock.BlockingAdvance(time.Second)
+	eventually(t, func() bool { return !worker.healthStatus.Healthy() })
+
+	// Recovers after a successful tick
+	atomic.StoreInt32(&failing, 0)
+	clock.BlockingAdvance(time.Second)
+	eventually(t, func() bool { return worker.healthStatus.Healthy() })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestHealthStaleness(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+		failing int32
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		if atomic.LoadInt32(&failing) == 1 {
+			return fmt.Errorf("oops")
+		}
+
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":       "60",
+		"worker_retry_enabled":       "true",
+		"worker_retry_initial_delay": "60s",
+		"worker_retry_multiplier":    "1",
+		"worker_unhealthy_staleness": "90s",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.TickFunc.History()) == 1 })
+	atomic.StoreInt32(&failing, 1)
+
+	// Staleness monitor and tick loop are both waiting
+	eventually(t, func() bool { return clock.BlockedOnAfter() == 2 })
+	clock.Advance(time.Second * 60)
+	eventually(t, func() bool { return len(spec.TickFunc.History()) == 2 })
+	assert.True(t, worker.healthStatus.Healthy())
+
+	clock.Advance(time.Second * 30)
+	eventually(t, func() bool { return !worker.healthStatus.Healthy() })
+
+	// Recovers after a successful tick
+	atomic.StoreInt32(&failing, 0)
+	clock.Advance(time.Second * 30)
+	eventually(t, func() bool { return worker.healthStatus.Healthy() })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
@@ -0,0 +1,33 @@
+// Package exponential computes exponentially growing delays shared by the
+// worker's retry backoff and the backoff of queue consumers.
+package exponential
+
+import (
+	"math"
+	"time"
+)
+
+// Delay returns the initial delay grown by the multiplier once for each attempt
+// after the first, capped at the max delay (see Cap).
+func Delay(initialDelay time.Duration, multiplier float64, attempt int, maxDelay time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	return Cap(float64(initialDelay)*math.Pow(multiplier, float64(attempt-1)), maxDelay)
+}
+
+// Cap converts the given delay to a duration no greater than the max delay. A
+// non-positive max delay imposes no limit beyond the largest representable
+// duration.
+func Cap(delay float64, maxDelay time.Duration) time.Duration {
+	if maxDelay > 0 && delay > float64(maxDelay) {
+		return maxDelay
+	}
+
+	if delay >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,214 @@
+package workerbase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// MetricsCollector records measurements of the ticks of one or more workers,
+// labelled by worker name (see WithName). Implementations must be safe for
+// concurrent use.
+type MetricsCollector interface {
+	// TickStarted is called when the named worker begins a tick.
+	TickStarted(worker string)
+
+	// TickFinished is called when the named worker finishes a tick that began
+	// at the given time and ran for the given duration. A nil error denotes a
+	// successful tick.
+	TickFinished(worker string, started time.Time, duration time.Duration, err error)
+}
+
+// SkippedSlotsRecorder is implemented by a MetricsCollector that records the
+// fixed-rate slots a worker skips after a tick overruns its interval (see
+// WORKER_MISSED_TICK_POLICY).
+type SkippedSlotsRecorder interface {
+	// SlotsSkipped is called when the named worker skips n slots.
+	SlotsSkipped(worker string, n int64)
+}
+
+func metricsMiddleware(clock glock.Clock, worker string, collector MetricsCollector) TickMiddleware {
+	return func(next TickFunc) TickFunc {
+		return func(ctx context.Context) error {
+			collector.TickStarted(worker)
+			started := clock.Now()
+			err := next(ctx)
+			collector.TickFinished(worker, started, clock.Since(started), err)
+			return err
+		}
+	}
+}
+
+// DefaultDurationBuckets are the upper bounds (in seconds) of the tick duration
+// histogram buckets used when no buckets are supplied to NewPrometheusCollector.
+var DefaultDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// PrometheusCollector is a MetricsCollector that renders its measurements in
+// the Prometheus text exposition format. It does not depend on a Prometheus
+// client library and may be served directly as an HTTP handler.
+type PrometheusCollector struct {
+	buckets []float64
+	mutex   sync.Mutex
+	workers map[string]*workerMetrics
+}
+
+type workerMetrics struct {
+	ticks        int64
+	errors       int64
+	skippedSlots int64
+	inFlight     int64
+	lastSuccess  time.Time
+	bucketCounts []int64
+	durationSum  float64
+}
+
+var _ MetricsCollector = &PrometheusCollector{}
+var _ SkippedSlotsRecorder = &PrometheusCollector{}
+var _ http.Handler = &PrometheusCollector{}
+
+// NewPrometheusCollector creates a new PrometheusCollector whose tick duration
+// histogram uses the given bucket upper bounds (in seconds). If no buckets are
+// supplied, DefaultDurationBuckets is used.
+func NewPrometheusCollector(buckets ...float64) *PrometheusCollector {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusCollector{
+		buckets: buckets,
+		workers: map[string]*workerMetrics{},
+	}
+}
+
+// TickStarted increments the in-flight gauge of the named worker.
+func (c *PrometheusCollector) TickStarted(worker string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.metricsFor(worker).inFlight++
+}
+
+// TickFinished decrements the in-flight gauge of the named worker and records
+// the tick's outcome and duration.
+func (c *PrometheusCollector) TickFinished(worker string, started time.Time, duration time.Duration, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	m := c.metricsFor(worker)
+	m.inFlight--
+	m.ticks++
+	if err != nil {
+		m.errors++
+	} else {
+		m.lastSuccess = started.Add(duration)
+	}
+
+	seconds := duration.Seconds()
+	m.durationSum += seconds
+	for i, bound := range c.buckets {
+		if seconds <= bound {
+			m.bucketCounts[i]++
+		}
+	}
+}
+
+// SlotsSkipped increments the skipped slot counter of the named worker.
+func (c *PrometheusCollector) SlotsSkipped(worker string, n int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.metricsFor(worker).skippedSlots += n
+}
+
+func (c *PrometheusCollector) metricsFor(worker string) *workerMetrics {
+	m, ok := c.workers[worker]
+	if !ok {
+		m = &workerMetrics{bucketCounts: make([]int64, len(c.buckets))}
+		c.workers[worker] = m
+	}
+
+	return m
+}
+
+// WriteTo writes the current measurements to w in the Prometheus text exposition
+// format.
+func (c *PrometheusCollector) WriteTo(w io.Writer) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	names := make([]string, 0, len(c.workers))
+	for name := range c.workers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	writeMetric := func(name, kind, help string, write func(label string, m *workerMetrics)) {
+		fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(buf, "# TYPE %s %s\n", name, kind)
+
+		for _, worker := range names {
+			write(fmt.Sprintf(`worker="%s"`, escapeLabelValue(worker)), c.workers[worker])
+		}
+	}
+
+	writeMetric("workerbase_ticks_total", "counter", "The number of completed ticks.", func(label string, m *workerMetrics) {
+		fmt.Fprintf(buf, "workerbase_ticks_total{%s} %d\n", label, m.ticks)
+	})
+	writeMetric("workerbase_tick_errors_total", "counter", "The number of ticks that returned an error.", func(label string, m *workerMetrics) {
+		fmt.Fprintf(buf, "workerbase_tick_errors_total{%s} %d\n", label, m.errors)
+	})
+	writeMetric("workerbase_tick_duration_seconds", "histogram", "The duration of completed ticks.", func(label string, m *workerMetrics) {
+		for i, bound := range c.buckets {
+			fmt.Fprintf(buf, "workerbase_tick_duration_seconds_bucket{%s,le=\"%s\"} %d\n", label, formatFloat(bound), m.bucketCounts[i])
+		}
+		fmt.Fprintf(buf, "workerbase_tick_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", label, m.ticks)
+		fmt.Fprintf(buf, "workerbase_tick_duration_seconds_sum{%s} %s\n", label, formatFloat(m.durationSum))
+		fmt.Fprintf(buf, "workerbase_tick_duration_seconds_count{%s} %d\n", label, m.ticks)
+	})
+	writeMetric("workerbase_last_success_timestamp_seconds", "gauge", "The time at which the last successful tick completed.", func(label string, m *workerMetrics) {
+		timestamp := 0.0
+		if !m.lastSuccess.IsZero() {
+			timestamp = float64(m.lastSuccess.UnixNano()) / float64(time.Second)
+		}
+
+		fmt.Fprintf(buf, "workerbase_last_success_timestamp_seconds{%s} %s\n", label, formatFloat(timestamp))
+	})
+	writeMetric("workerbase_ticks_in_flight", "gauge", "The number of ticks currently running.", func(label string, m *workerMetrics) {
+		fmt.Fprintf(buf, "workerbase_ticks_in_flight{%s} %d\n", label, m.inFlight)
+	})
+	writeMetric("workerbase_skipped_slots_total", "counter", "The number of fixed-rate slots skipped after a tick overran its interval.", func(label string, m *workerMetrics) {
+		fmt.Fprintf(buf, "workerbase_skipped_slots_total{%s} %d\n", label, m.skippedSlots)
+	})
+
+	return buf.WriteTo(w)
+}
+
+// ServeHTTP writes the current measurements in the Prometheus text exposition
+// format.
+func (c *PrometheusCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = c.WriteTo(w)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,129 @@
+package workerbase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	var (
+		spec      = NewMockWorkerSpecFinalizer()
+		clock     = glock.NewMockClock()
+		collector = NewPrometheusCollector(1, 5)
+		errChan   = make(chan error)
+	)
+
+	start := time.Unix(1600000000, 0)
+	clock.SetCurrent(start)
+
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		clock.Advance(time.Second * 2)
+		return nil
+	})
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	worker := makeWorker(spec, clock, WithName("mailer"), WithMetrics(collector))
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.TickFunc.History()) == 1 })
+	clock.BlockingAdvance(time.Second * 5)
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "oops")
+
+	expected := `# HELP workerbase_ticks_total The number of completed ticks.
+# TYPE workerbase_ticks_total counter
+workerbase_ticks_total{worker="mailer"} 2
+# HELP workerbase_tick_errors_total The number of ticks that returned an error.
+# TYPE workerbase_tick_errors_total counter
+workerbase_tick_errors_total{worker="mailer"} 1
+# HELP workerbase_tick_duration_seconds The duration of completed ticks.
+# TYPE workerbase_tick_duration_seconds histogram
+workerbase_tick_duration_seconds_bucket{worker="mailer",le="1"} 1
+workerbase_tick_duration_seconds_bucket{worker="mailer",le="5"} 2
+workerbase_tick_duration_seconds_bucket{worker="mailer",le="+Inf"} 2
+workerbase_tick_duration_seconds_sum{worker="mailer"} 2
+workerbase_tick_duration_seconds_count{worker="mailer"} 2
+# HELP workerbase_last_success_timestamp_seconds The time at which the last successful tick completed.
+# TYPE workerbase_last_success_timestamp_seconds gauge
+workerbase_last_success_timestamp_seconds{worker="mailer"} 1.600000002e+09
+# HELP workerbase_ticks_in_flight The number of ticks currently running.
+# TYPE workerbase_ticks_in_flight gauge
+workerbase_ticks_in_flight{worker="mailer"} 0
+# HELP workerbase_skipped_slots_total The number of fixed-rate slots skipped after a tick overran its interval.
+# TYPE workerbase_skipped_slots_total counter
+workerbase_skipped_slots_total{worker="mailer"} 0
+`
+
+	buf := &bytes.Buffer{}
+	_, err = collector.WriteTo(buf)
+	require.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestMetricsInFlight(t *testing.T) {
+	var (
+		spec        = NewMockWorkerSpecFinalizer()
+		clock       = glock.NewMockClock()
+		collector   = NewPrometheusCollector()
+		tickChan    = make(chan struct{}, 1)
+		releaseChan = make(chan struct{})
+		errChan     = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		<-releaseChan
+		return nil
+	})
+
+	worker := makeWorker(spec, clock, WithName("mailer"), WithMetrics(collector))
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, receiveStruct(tickChan))
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, recorder.Body.String(), `workerbase_ticks_in_flight{worker="mailer"} 1`)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+
+	close(releaseChan)
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestPrometheusCollectorEscapesLabels(t *testing.T) {
+	collector := NewPrometheusCollector()
+	collector.TickStarted("a\"b\\c\nd")
+
+	buf := &bytes.Buffer{}
+	_, err := collector.WriteTo(buf)
+	require.Nil(t, err)
+	assert.Contains(t, buf.String(), `workerbase_ticks_in_flight{worker="a\"b\\c\nd"} 1`)
+}
@@ -0,0 +1,126 @@
+package workerbase
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/nacelle/v2"
+)
+
+type (
+	// TickFunc is a single invocation of a worker spec's tick method.
+	TickFunc func(ctx context.Context) error
+
+	// TickMiddleware wraps a TickFunc with additional behavior. A middleware
+	// may act before and after invoking next, or may choose not to invoke
+	// next at all.
+	TickMiddleware func(next TickFunc) TickFunc
+)
+
+// chainMiddleware composes the given middleware around tick. The first
+// middleware is the outermost, and is the first to observe each tick.
+func chainMiddleware(tick TickFunc, middleware []TickMiddleware) TickFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		tick = middleware[i](tick)
+	}
+
+	return tick
+}
+
+// RecoveryMiddleware converts a panic within the remainder of the chain into
+// a PanicError. The worker always applies this middleware outside of any
+// middleware supplied via WithMiddleware.
+func RecoveryMiddleware() TickMiddleware {
+	return func(next TickFunc) TickFunc {
+		return func(ctx context.Context) (err error) {
+			defer func() {
+				if value := recover(); value != nil {
+					err = &PanicError{Value: value, Stack: debug.Stack()}
+				}
+			}()
+
+			return next(ctx)
+		}
+	}
+}
+
+// TimeoutMiddleware cancels the context of the remainder of the chain after
+// the given timeout. A tick whose context deadline is exceeded returns a
+// TickTimeoutError.
+func TimeoutMiddleware(timeout time.Duration) TickMiddleware {
+	return timeoutMiddleware(glock.NewRealClock(), timeout)
+}
+
+func timeoutMiddleware(clock glock.Clock, timeout time.Duration) TickMiddleware {
+	return func(next TickFunc) TickFunc {
+		return func(ctx context.Context) error {
+			tickCtx, cancel := glock.ContextWithTimeout(ctx, clock, timeout)
+			defer cancel()
+
+			err := next(tickCtx)
+			if tickCtx.Err() == context.DeadlineExceeded {
+				return &TickTimeoutError{Timeout: timeout, Err: err}
+			}
+
+			return err
+		}
+	}
+}
+
+// TimingMiddleware calls observe with the duration and result of each
+// invocation of the remainder of the chain.
+func TimingMiddleware(observe func(ctx context.Context, duration time.Duration, err error)) TickMiddleware {
+	return timingMiddleware(glock.NewRealClock(), observe)
+}
+
+func timingMiddleware(clock glock.Clock, observe func(ctx context.Context, duration time.Duration, err error)) TickMiddleware {
+	return func(next TickFunc) TickFunc {
+		return func(ctx context.Context) error {
+			started := clock.Now()
+			err := next(ctx)
+			observe(ctx, clock.Since(started), err)
+			return err
+		}
+	}
+}
+
+// LoggingMiddleware logs the beginning and end of each tick to the given
+// logger. Failed ticks are logged at the warning level.
+func LoggingMiddleware(logger nacelle.Logger) TickMiddleware {
+	return loggingMiddleware(glock.NewRealClock(), logger)
+}
+
+func loggingMiddleware(clock glock.Clock, logger nacelle.Logger) TickMiddleware {
+	timing := timingMiddleware(clock, func(ctx context.Context, duration time.Duration, err error) {
+		fields := tickLogFields(ctx)
+		fields["duration"] = duration
+
+		if err != nil {
+			logger.WarningWithFields(fields, "Tick failed after %s (%s)", duration, err)
+		} else {
+			logger.DebugWithFields(fields, "Tick completed in %s", duration)
+		}
+	})
+
+	return func(next TickFunc) TickFunc {
+		next = timing(next)
+
+		return func(ctx context.Context) error {
+			logger.DebugWithFields(tickLogFields(ctx), "Beginning tick")
+			return next(ctx)
+		}
+	}
+}
+
+func tickLogFields(ctx context.Context) nacelle.LogFields {
+	fields := nacelle.LogFields{
+		"tick": TickNumberFromContext(ctx),
+	}
+	if name := WorkerNameFromContext(ctx); name != "" {
+		fields["worker"] = name
+	}
+
+	return fields
+}
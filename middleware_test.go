@@ -0,0 +1,191 @@
+package workerbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		events  []string
+		mutex   sync.Mutex
+		errChan = make(chan error)
+	)
+
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+	getEvents := func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string(nil), events...)
+	}
+	middleware := func(name string) TickMiddleware {
+		return func(next TickFunc) TickFunc {
+			return func(ctx context.Context) error {
+				record(fmt.Sprintf("%s:%d", name, TickNumberFromContext(ctx)))
+				err := next(ctx)
+				record(name)
+				return err
+			}
+		}
+	}
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		record("tick")
+		return nil
+	})
+
+	worker := makeWorker(spec, clock, WithMiddleware(middleware("a"), middleware("b")), WithMiddleware(middleware("c")))
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(getEvents()) == 7 })
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	assert.Equal(t, []string{"a:1", "b:1", "c:1", "tick", "c", "b", "a"}, getEvents())
+}
+
+func TestMiddlewareObservesTickResult(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		spec    = &resultWorkerSpec{tickRecorder: tickRecorder{clock: clock}, results: []TickResult{{MoreWorkPending: true}}}
+		errChan = make(chan error)
+	)
+
+	skip := func(next TickFunc) TickFunc {
+		return func(ctx context.Context) error {
+			if TickNumberFromContext(ctx) == 3 {
+				return nil
+			}
+
+			return next(ctx)
+		}
+	}
+
+	worker := makeWorker(spec, clock, WithMiddleware(skip))
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	// The first tick's result is honored through the chain
+	eventually(t, func() bool { return len(spec.getTimes()) == 2 })
+
+	// The skipped third tick waits the regular interval
+	clock.BlockingAdvance(time.Second * 5)
+	consistently(t, func() bool { return len(spec.getTimes()) == 2 })
+	clock.BlockingAdvance(time.Second * 5)
+	eventually(t, func() bool { return len(spec.getTimes()) == 3 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	tick := chainMiddleware(func(ctx context.Context) error {
+		panic("oops")
+	}, []TickMiddleware{RecoveryMiddleware()})
+
+	var panicErr *PanicError
+	err := tick(context.Background())
+	require.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "oops", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		errChan = make(chan error)
+	)
+
+	tick := chainMiddleware(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, []TickMiddleware{timeoutMiddleware(clock, time.Second*10)})
+
+	go func() {
+		errChan <- tick(context.Background())
+	}()
+
+	clock.BlockingAdvance(time.Second * 10)
+	err := readErrorValue(t, errChan)
+
+	var timeoutErr *TickTimeoutError
+	require.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, time.Second*10, timeoutErr.Timeout)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestTimeoutMiddlewareWrapsTickError(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		tickErr = errors.New("oops")
+		errChan = make(chan error)
+	)
+
+	tick := chainMiddleware(func(ctx context.Context) error {
+		<-ctx.Done()
+		return fmt.Errorf("failed to query: %w", tickErr)
+	}, []TickMiddleware{timeoutMiddleware(clock, time.Second*10)})
+
+	go func() {
+		errChan <- tick(context.Background())
+	}()
+
+	clock.BlockingAdvance(time.Second * 10)
+	err := readErrorValue(t, errChan)
+
+	// Both the timeout and the error returned by the tick are exposed
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, errors.Is(err, tickErr))
+	assert.EqualError(t, err, "tick exceeded timeout of 10s: failed to query: oops")
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	var (
+		clock    = glock.NewMockClock()
+		duration time.Duration
+		observed error
+	)
+
+	tick := chainMiddleware(func(ctx context.Context) error {
+		clock.Advance(time.Second * 3)
+		return fmt.Errorf("oops")
+	}, []TickMiddleware{timingMiddleware(clock, func(ctx context.Context, d time.Duration, err error) {
+		duration, observed = d, err
+	})})
+
+	err := tick(context.Background())
+	assert.EqualError(t, err, "oops")
+	assert.EqualError(t, observed, "oops")
+	assert.Equal(t, time.Second*3, duration)
+}
@@ -1,23 +1,80 @@
 package workerbase
 
-import "github.com/go-nacelle/config/v3"
+import (
+	"math/rand"
+
+	"github.com/go-nacelle/config/v3"
+)
 
 type (
 	options struct {
-		tagModifiers []config.TagModifier
+		name           string
+		tagModifiers   []config.TagModifier
+		schedule       string
+		triggerSources []<-chan struct{}
+		middleware     []TickMiddleware
+		metrics        MetricsCollector
+		tracer         Tracer
+		random         func() float64
 	}
 
 	// ConfigFunc is a function used to configure an instance of a Worker.
 	ConfigFunc func(*options)
 )
 
+// WithName sets the name of the worker, which is made available to the spec's
+// tick method via WorkerNameFromContext.
+func WithName(name string) ConfigFunc {
+	return func(o *options) { o.name = name }
+}
+
 // WithTagModifiers applies the given tag modifiers on config load.
 func WithTagModifiers(modifiers ...config.TagModifier) ConfigFunc {
 	return func(o *options) { o.tagModifiers = append(o.tagModifiers, modifiers...) }
 }
 
+// WithSchedule sets the cron expression that controls when the worker ticks
+// if no schedule is supplied via configuration. The expression may have five
+// or six (with leading seconds) fields, or be a descriptor such as @hourly.
+func WithSchedule(expression string) ConfigFunc {
+	return func(o *options) { o.schedule = expression }
+}
+
+// WithTriggerSource wakes the worker to begin a tick each time a value is received
+// from the given channel (see Worker.Trigger).
+func WithTriggerSource(source <-chan struct{}) ConfigFunc {
+	return func(o *options) { o.triggerSources = append(o.triggerSources, source) }
+}
+
+// WithMiddleware wraps each invocation of the spec's tick method with the given
+// middleware. Middleware is applied in the order supplied, the first being the
+// outermost. The worker's tick timeout applies within all middleware.
+func WithMiddleware(middleware ...TickMiddleware) ConfigFunc {
+	return func(o *options) { o.middleware = append(o.middleware, middleware...) }
+}
+
+// WithMetrics records the count, outcome, and duration of each tick with the
+// given collector, labelled by the worker's name.
+func WithMetrics(collector MetricsCollector) ConfigFunc {
+	return func(o *options) { o.metrics = collector }
+}
+
+// WithTracer wraps each tick in a span begun by the given tracer. The span is
+// annotated with the worker name, tick number, and scheduled and actual start
+// times of the tick.
+func WithTracer(tracer Tracer) ConfigFunc {
+	return func(o *options) { o.tracer = tracer }
+}
+
+// WithRandomSource sets the source of random values in [0, 1) used to jitter tick
+// intervals and retry delays. The source must be safe for concurrent use. By
+// default, the worker uses math/rand.
+func WithRandomSource(random func() float64) ConfigFunc {
+	return func(o *options) { o.random = random }
+}
+
 func getOptions(configs []ConfigFunc) *options {
-	options := &options{}
+	options := &options{random: rand.Float64}
 	for _, f := range configs {
 		f(options)
 	}
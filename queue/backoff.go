@@ -0,0 +1,24 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/go-nacelle/workerbase/internal/exponential"
+)
+
+// Backoff returns the delay before a job is redelivered after the given
+// one-based attempt failed.
+type Backoff func(attempt int) time.Duration
+
+// ExponentialBackoff returns a Backoff whose delay starts at the initial delay
+// and grows by the given multiplier with each failed attempt. The delay is capped
+// at the max delay unless the max delay is zero.
+func ExponentialBackoff(initialDelay time.Duration, multiplier float64, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return exponential.Delay(initialDelay, multiplier, attempt, maxDelay)
+	}
+}
+
+func constantBackoff(delay time.Duration) Backoff {
+	return func(attempt int) time.Duration { return delay }
+}
@@ -0,0 +1,32 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 2, time.Second*10)
+
+	var delays []time.Duration
+	for attempt := 1; attempt <= 6; attempt++ {
+		delays = append(delays, backoff(attempt))
+	}
+
+	assert.Equal(t, []time.Duration{
+		time.Second,
+		time.Second * 2,
+		time.Second * 4,
+		time.Second * 8,
+		time.Second * 10,
+		time.Second * 10,
+	}, delays)
+}
+
+func TestExponentialBackoffUncapped(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 10, 0)
+	assert.Equal(t, time.Second*1000, backoff(4))
+	assert.Equal(t, time.Duration(1<<63-1), backoff(100))
+}
@@ -0,0 +1,147 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/nacelle/v2"
+	"github.com/go-nacelle/workerbase"
+)
+
+// Handler processes a single job. A nil error acknowledges the job, and a
+// non-nil error returns the job to the queue for redelivery. A panic within
+// the handler is treated as a failed attempt.
+type Handler func(ctx context.Context, job Job) error
+
+// Consumer is a worker spec that dequeues and handles one job per tick. After a
+// job is handled successfully the worker ticks again immediately. Once the queue
+// is empty or a job fails, the worker waits for its configured interval or
+// schedule before polling again.
+// An error from the queue itself is returned from the tick. A job that can no
+// longer be acknowledged or returned because its visibility timeout lapsed and it
+// was redelivered to another consumer is logged and otherwise left to that
+// consumer.
+//
+// A failed job is redelivered after a delay chosen by the consumer's backoff.
+// Once a job has failed the configured maximum number of attempts, it is moved
+// with its error history to the consumer's dead-letter store.
+type Consumer struct {
+	Logger            nacelle.Logger `service:"logger" optional:"true"`
+	queue             Queue
+	handler           Handler
+	clock             glock.Clock
+	visibilityTimeout time.Duration
+	backoff           Backoff
+	maxAttempts       int
+	deadLetters       DeadLetterStore
+}
+
+var _ workerbase.ResultTicker = &Consumer{}
+
+// NewConsumer creates a worker spec that handles the jobs of the given queue.
+func NewConsumer(queue Queue, handler Handler, configs ...ConfigFunc) *Consumer {
+	return newConsumer(queue, handler, glock.NewRealClock(), configs...)
+}
+
+func newConsumer(queue Queue, handler Handler, clock glock.Clock, configs ...ConfigFunc) *Consumer {
+	options := getOptions(configs)
+
+	return &Consumer{
+		queue:             queue,
+		handler:           handler,
+		clock:             clock,
+		visibilityTimeout: options.visibilityTimeout,
+		backoff:           options.backoff,
+		maxAttempts:       options.maxAttempts,
+		deadLetters:       options.deadLetters,
+	}
+}
+
+func (c *Consumer) Init(ctx context.Context) error {
+	if c.Logger == nil {
+		c.Logger = nacelle.NewNilLogger()
+	}
+
+	return nil
+}
+
+func (c *Consumer) Tick(ctx context.Context) error {
+	_, err := c.TickWithResult(ctx)
+	return err
+}
+
+func (c *Consumer) TickWithResult(ctx context.Context) (workerbase.TickResult, error) {
+	job, ok, err := c.queue.Dequeue(ctx, c.visibilityTimeout)
+	if err != nil || !ok {
+		return workerbase.TickResult{}, err
+	}
+
+	if err := c.handle(ctx, job); err != nil {
+		// Wait for the next interval rather than ticking again immediately, so
+		// that a run of failing jobs does not become a busy loop
+		return workerbase.TickResult{}, c.settle(job, c.fail(ctx, job, err))
+	}
+
+	if err := c.settle(job, c.queue.Ack(ctx, job.Receipt)); err != nil {
+		return workerbase.TickResult{}, err
+	}
+
+	return workerbase.TickResult{MoreWorkPending: true}, nil
+}
+
+// handle invokes the handler, converting a panic into an error.
+func (c *Consumer) handle(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = &workerbase.PanicError{Value: value, Stack: debug.Stack()}
+		}
+	}()
+
+	return c.handler(ctx, job)
+}
+
+// fail returns a failed job to the queue, or moves it to the dead-letter store
+// if it has exhausted its attempts.
+func (c *Consumer) fail(ctx context.Context, job Job, cause error) error {
+	if c.maxAttempts <= 0 || job.Attempts < c.maxAttempts {
+		c.Logger.Warning("Failed to handle job %s on attempt %d (%s)", job.ID, job.Attempts, cause)
+		return c.queue.Nack(ctx, job.Receipt, c.backoff(job.Attempts), cause)
+	}
+
+	if c.deadLetters == nil {
+		c.Logger.Error("Discarding job %s after %d failed attempts (%s)", job.ID, job.Attempts, cause)
+		return c.queue.Ack(ctx, job.Receipt)
+	}
+
+	now := c.clock.Now()
+	job.Errors = append(job.Errors, JobError{
+		Attempt:  job.Attempts,
+		Message:  cause.Error(),
+		FailedAt: now,
+	})
+
+	dead := DeadJob{Job: job, DeadAt: now}
+	dead.Receipt = ""
+
+	if err := c.deadLetters.Add(ctx, dead); err != nil {
+		return err
+	}
+
+	c.Logger.Error("Moved job %s to the dead-letter store after %d failed attempts (%s)", job.ID, job.Attempts, cause)
+	return c.queue.Ack(ctx, job.Receipt)
+}
+
+// settle returns the given error from acknowledging or returning a job, unless
+// the job's receipt is no longer current. The consumer's lease on such a job has
+// been lost, and the job is left to whichever consumer now holds it.
+func (c *Consumer) settle(job Job, err error) error {
+	if errors.Is(err, ErrJobNotFound) {
+		c.Logger.Warning("Lost lease on job %s on attempt %d", job.ID, job.Attempts)
+		return nil
+	}
+
+	return err
+}
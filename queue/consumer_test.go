@@ -0,0 +1,364 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/workerbase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumerAck(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		queue   = newMemoryQueue(clock)
+		ctx     = context.Background()
+		handled []Job
+	)
+
+	id, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+		handled = append(handled, job)
+		return nil
+	})
+	require.Nil(t, consumer.Init(ctx))
+
+	result, err := consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, workerbase.TickResult{MoreWorkPending: true}, result)
+	require.Len(t, handled, 1)
+	assert.Equal(t, id, handled[0].ID)
+
+	// Acknowledged jobs are not redelivered
+	clock.Advance(time.Hour)
+	result, err = consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, workerbase.TickResult{}, result)
+	assert.Len(t, handled, 1)
+}
+
+func TestConsumerNack(t *testing.T) {
+	var (
+		clock    = glock.NewMockClock()
+		queue    = newMemoryQueue(clock)
+		ctx      = context.Background()
+		attempts []int
+	)
+
+	_, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+		attempts = append(attempts, job.Attempts)
+		return errors.New("oops")
+	}, WithNackDelay(time.Second*5))
+	require.Nil(t, consumer.Init(ctx))
+
+	// A failed job does not cause the worker to tick again immediately
+	result, err := consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, workerbase.TickResult{}, result)
+
+	clock.Advance(time.Second*5 - time.Millisecond)
+	_, err = consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []int{1}, attempts)
+
+	clock.Advance(time.Millisecond)
+	_, err = consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, attempts)
+}
+
+func TestConsumerBackoff(t *testing.T) {
+	var (
+		clock    = glock.NewMockClock()
+		queue    = newMemoryQueue(clock)
+		ctx      = context.Background()
+		attempts []int
+	)
+
+	_, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+		attempts = append(attempts, job.Attempts)
+		return errors.New("oops")
+	}, WithBackoff(ExponentialBackoff(time.Second, 2, 0)))
+	require.Nil(t, consumer.Init(ctx))
+
+	for _, delay := range []time.Duration{time.Second, time.Second * 2, time.Second * 4} {
+		_, err = consumer.TickWithResult(ctx)
+		require.Nil(t, err)
+
+		clock.Advance(delay - time.Millisecond)
+		result, err := consumer.TickWithResult(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, workerbase.TickResult{}, result)
+
+		clock.Advance(time.Millisecond)
+	}
+
+	_, err = consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, attempts)
+}
+
+func TestConsumerDefaultBackoff(t *testing.T) {
+	var (
+		clock    = glock.NewMockClock()
+		queue    = newMemoryQueue(clock)
+		ctx      = context.Background()
+		attempts []int
+	)
+
+	_, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+		attempts = append(attempts, job.Attempts)
+		return errors.New("oops")
+	})
+	require.Nil(t, consumer.Init(ctx))
+
+	for _, delay := range []time.Duration{time.Second, time.Second * 2, time.Second * 4} {
+		_, err = consumer.TickWithResult(ctx)
+		require.Nil(t, err)
+		clock.Advance(delay)
+	}
+
+	_, err = consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, attempts)
+
+	// The delay is capped at five minutes
+	clock.Advance(time.Hour)
+	for i := 0; i < 10; i++ {
+		_, err = consumer.TickWithResult(ctx)
+		require.Nil(t, err)
+		clock.Advance(time.Minute * 5)
+	}
+	assert.Len(t, attempts, 14)
+}
+
+func TestConsumerDeadLetter(t *testing.T) {
+	var (
+		clock       = glock.NewMockClock()
+		queue       = newMemoryQueue(clock)
+		deadLetters = NewMemoryDeadLetterStore()
+		ctx         = context.Background()
+	)
+
+	id, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	consumer := newConsumer(queue, func(ctx context.Context, job Job) error {
+		return fmt.Errorf("failure %d", job.Attempts)
+	}, clock, WithMaxAttempts(2), WithDeadLetterStore(deadLetters))
+	require.Nil(t, consumer.Init(ctx))
+
+	start := clock.Now()
+
+	for i := 0; i < 2; i++ {
+		result, err := consumer.TickWithResult(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, workerbase.TickResult{}, result)
+		clock.Advance(time.Second)
+	}
+
+	// The job is no longer in the queue
+	clock.Advance(time.Hour)
+	_, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	assert.False(t, ok)
+
+	jobs, err := deadLetters.List(ctx)
+	require.Nil(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, id, jobs[0].ID)
+	assert.Equal(t, 2, jobs[0].Attempts)
+	assert.Equal(t, start.Add(time.Second), jobs[0].DeadAt)
+
+	var messages []string
+	for _, jobErr := range jobs[0].Errors {
+		messages = append(messages, fmt.Sprintf("%d: %s", jobErr.Attempt, jobErr.Message))
+	}
+	assert.Equal(t, []string{"1: failure 1", "2: failure 2"}, messages)
+}
+
+func TestConsumerMaxAttemptsWithoutDeadLetterStore(t *testing.T) {
+	var (
+		clock = glock.NewMockClock()
+		queue = newMemoryQueue(clock)
+		ctx   = context.Background()
+	)
+
+	_, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+		return errors.New("oops")
+	}, WithMaxAttempts(1))
+	require.Nil(t, consumer.Init(ctx))
+
+	_, err = consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+
+	clock.Advance(time.Hour)
+	_, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestConsumerPanic(t *testing.T) {
+	var (
+		clock = glock.NewMockClock()
+		queue = newMemoryQueue(clock)
+		ctx   = context.Background()
+	)
+
+	_, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+		panic("oops")
+	})
+	require.Nil(t, consumer.Init(ctx))
+
+	_, err = consumer.TickWithResult(ctx)
+	require.Nil(t, err)
+
+	clock.Advance(time.Second)
+
+	job, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Len(t, job.Errors, 1)
+	assert.Equal(t, "tick panicked: oops", job.Errors[0].Message)
+}
+
+func TestConsumerFailingJobDoesNotBlockQueue(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		queue   = newMemoryQueue(clock)
+		ctx     = context.Background()
+		handled []string
+	)
+
+	enqueueAll(t, queue, "bad", "good")
+
+	consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+		handled = append(handled, string(job.Payload))
+		if string(job.Payload) == "bad" {
+			return errors.New("oops")
+		}
+		return nil
+	}, WithNackDelay(0))
+	require.Nil(t, consumer.Init(ctx))
+
+	for i := 0; i < 3; i++ {
+		_, err := consumer.TickWithResult(ctx)
+		require.Nil(t, err)
+	}
+
+	assert.Equal(t, []string{"bad", "good", "bad"}, handled)
+}
+
+func TestConsumerLostLease(t *testing.T) {
+	for name, handlerErr := range map[string]error{"ack": nil, "nack": errors.New("oops")} {
+		handlerErr := handlerErr
+
+		t.Run(name+" after current holder", func(t *testing.T) {
+			var (
+				clock = glock.NewMockClock()
+				queue = newMemoryQueue(clock)
+				ctx   = context.Background()
+			)
+
+			_, err := queue.Enqueue(ctx, []byte("a"))
+			require.Nil(t, err)
+
+			consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+				// The visibility timeout lapses and another consumer handles the job
+				clock.Advance(time.Minute)
+				redelivered, ok, err := queue.Dequeue(ctx, time.Minute)
+				require.Nil(t, err)
+				require.True(t, ok)
+				require.Nil(t, queue.Ack(ctx, redelivered.Receipt))
+
+				return handlerErr
+			})
+			require.Nil(t, consumer.Init(ctx))
+
+			_, err = consumer.TickWithResult(ctx)
+			assert.Nil(t, err)
+		})
+
+		t.Run(name+" before current holder", func(t *testing.T) {
+			var (
+				clock       = glock.NewMockClock()
+				queue       = newMemoryQueue(clock)
+				ctx         = context.Background()
+				redelivered Job
+			)
+
+			_, err := queue.Enqueue(ctx, []byte("a"))
+			require.Nil(t, err)
+
+			consumer := NewConsumer(queue, func(ctx context.Context, job Job) error {
+				// The visibility timeout lapses and another consumer takes the job,
+				// but is still handling it when this consumer finishes
+				clock.Advance(time.Minute)
+				var ok bool
+				redelivered, ok, err = queue.Dequeue(ctx, time.Minute)
+				require.Nil(t, err)
+				require.True(t, ok)
+
+				return handlerErr
+			}, WithNackDelay(0))
+			require.Nil(t, consumer.Init(ctx))
+
+			_, err = consumer.TickWithResult(ctx)
+			assert.Nil(t, err)
+
+			// The job is neither removed nor redelivered while the other consumer
+			// holds it, and the other consumer can still acknowledge it
+			_, ok, err := queue.Dequeue(ctx, time.Minute)
+			require.Nil(t, err)
+			assert.False(t, ok)
+			require.Nil(t, queue.Ack(ctx, redelivered.Receipt))
+		})
+	}
+}
+
+func TestConsumerQueueError(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		queue    = &errorQueue{err: errors.New("oops")}
+		consumer = NewConsumer(queue, func(ctx context.Context, job Job) error { return nil })
+	)
+	require.Nil(t, consumer.Init(ctx))
+
+	_, err := consumer.TickWithResult(ctx)
+	assert.EqualError(t, err, "oops")
+}
+
+type errorQueue struct {
+	Queue
+	err error
+}
+
+func (q *errorQueue) Enqueue(ctx context.Context, payload []byte) (string, error) {
+	return "", q.err
+}
+
+func (q *errorQueue) Dequeue(ctx context.Context, visibilityTimeout time.Duration) (Job, bool, error) {
+	return Job{}, false, q.err
+}
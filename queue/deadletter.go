@@ -0,0 +1,92 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// DeadJob is a job that exhausted its attempts, along with its error history.
+type DeadJob struct {
+	Job
+
+	// DeadAt is the time at which the job was moved to the dead-letter store.
+	DeadAt time.Time `json:"dead_at"`
+}
+
+// DeadLetterStore holds jobs that a Consumer gave up on. Dead jobs can be listed
+// and inspected, and returned to a queue with Redrive.
+type DeadLetterStore interface {
+	// Add adds a job to the store.
+	Add(ctx context.Context, job DeadJob) error
+
+	// List returns the jobs in the store, in the order they were added.
+	List(ctx context.Context) ([]DeadJob, error)
+
+	// Get returns the job with the given ID.
+	Get(ctx context.Context, id string) (DeadJob, error)
+
+	// Remove removes the job with the given ID from the store.
+	Remove(ctx context.Context, id string) error
+}
+
+// Redrive enqueues the payload of the given dead job onto the given queue as a
+// new job, then removes the dead job from the store. The ID of the new job is
+// returned. The new job starts with no attempts or error history.
+func Redrive(ctx context.Context, store DeadLetterStore, queue Queue, id string) (string, error) {
+	job, err := store.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	newID, err := queue.Enqueue(ctx, job.Payload)
+	if err != nil {
+		return "", err
+	}
+
+	if err := store.Remove(ctx, id); err != nil {
+		return "", err
+	}
+
+	return newID, nil
+}
+
+// deadList is the state shared by the dead-letter store implementations. It is
+// not safe for concurrent use.
+type deadList struct {
+	Jobs []DeadJob `json:"jobs"`
+}
+
+func (l *deadList) add(job DeadJob) {
+	l.Jobs = append(l.Jobs, job)
+}
+
+func (l *deadList) list() []DeadJob {
+	return append([]DeadJob(nil), l.Jobs...)
+}
+
+func (l *deadList) get(id string) (DeadJob, error) {
+	for _, job := range l.Jobs {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+
+	return DeadJob{}, ErrJobNotFound
+}
+
+func (l *deadList) remove(id string) error {
+	for i, job := range l.Jobs {
+		if job.ID == id {
+			l.Jobs = append(l.Jobs[:i], l.Jobs[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrJobNotFound
+}
+
+// clone returns a copy of the list, sharing job payloads and error histories,
+// which are never modified in place.
+func (l *deadList) clone() *deadList {
+	return &deadList{Jobs: append([]DeadJob(nil), l.Jobs...)}
+}
@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterStores(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testDeadLetterStore(t, func() DeadLetterStore {
+			return NewMemoryDeadLetterStore()
+		})
+	})
+
+	t.Run("file", func(t *testing.T) {
+		testDeadLetterStore(t, func() DeadLetterStore {
+			store, err := NewFileDeadLetterStore(filepath.Join(t.TempDir(), "dead.json"))
+			require.Nil(t, err)
+			return store
+		})
+	})
+}
+
+func testDeadLetterStore(t *testing.T, makeStore func() DeadLetterStore) {
+	var (
+		store = makeStore()
+		ctx   = context.Background()
+		job1  = DeadJob{Job: Job{ID: "a", Payload: []byte("a"), Attempts: 3}}
+		job2  = DeadJob{Job: Job{ID: "b", Payload: []byte("b"), Attempts: 3}}
+	)
+
+	require.Nil(t, store.Add(ctx, job1))
+	require.Nil(t, store.Add(ctx, job2))
+
+	jobs, err := store.List(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []DeadJob{job1, job2}, jobs)
+
+	job, err := store.Get(ctx, "b")
+	require.Nil(t, err)
+	assert.Equal(t, job2, job)
+
+	require.Nil(t, store.Remove(ctx, "a"))
+	assert.Equal(t, ErrJobNotFound, store.Remove(ctx, "a"))
+
+	_, err = store.Get(ctx, "a")
+	assert.Equal(t, ErrJobNotFound, err)
+
+	jobs, err = store.List(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []DeadJob{job2}, jobs)
+}
+
+func TestFileDeadLetterStorePersistence(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "dead.json")
+		ctx  = context.Background()
+		now  = time.Now().UTC().Truncate(time.Second)
+		job  = DeadJob{
+			Job: Job{
+				ID:         "a",
+				Payload:    []byte("a"),
+				Attempts:   2,
+				EnqueuedAt: now,
+				Errors: []JobError{
+					{Attempt: 1, Message: "oops", FailedAt: now},
+					{Attempt: 2, Message: "oops again", FailedAt: now},
+				},
+			},
+			DeadAt: now,
+		}
+	)
+
+	store, err := NewFileDeadLetterStore(path)
+	require.Nil(t, err)
+	require.Nil(t, store.Add(ctx, job))
+
+	// Reopen the store as if the process had restarted
+	store, err = NewFileDeadLetterStore(path)
+	require.Nil(t, err)
+
+	jobs, err := store.List(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []DeadJob{job}, jobs)
+}
+
+func TestRedrive(t *testing.T) {
+	var (
+		store = NewMemoryDeadLetterStore()
+		queue = newMemoryQueue(glock.NewMockClock())
+		ctx   = context.Background()
+	)
+
+	require.Nil(t, store.Add(ctx, DeadJob{Job: Job{
+		ID:       "a",
+		Payload:  []byte("a"),
+		Attempts: 3,
+		Errors:   []JobError{{Attempt: 3, Message: "oops"}},
+	}}))
+
+	id, err := Redrive(ctx, store, queue, "a")
+	require.Nil(t, err)
+
+	job, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, id, job.ID)
+	assert.Equal(t, []byte("a"), job.Payload)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Empty(t, job.Errors)
+
+	jobs, err := store.List(ctx)
+	require.Nil(t, err)
+	assert.Empty(t, jobs)
+
+	_, err = Redrive(ctx, store, queue, "a")
+	assert.Equal(t, ErrJobNotFound, err)
+}
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DelayedJob is a job held by a DelayedStore until its run-at time.
+type DelayedJob struct {
+	// ID uniquely identifies the job within its store.
+	ID string `json:"id"`
+
+	// Payload is the opaque content of the job.
+	Payload []byte `json:"payload"`
+
+	// RunAt is the time at which the job becomes due.
+	RunAt time.Time `json:"run_at"`
+}
+
+// DelayedStore holds jobs ordered by the time at which they should run. The
+// store does not consult a clock itself; due times are evaluated against the
+// time supplied by the caller.
+type DelayedStore interface {
+	// Schedule adds a job with the given payload to run at the given time and
+	// returns its ID.
+	Schedule(ctx context.Context, payload []byte, runAt time.Time) (string, error)
+
+	// Due returns the job with the earliest run-at time that is not after the
+	// given time, without removing it. If no job is due, false is returned.
+	Due(ctx context.Context, now time.Time) (DelayedJob, bool, error)
+
+	// Remove removes a job from the store. This is used both to cancel a job
+	// and to discard it once it has been handed off.
+	Remove(ctx context.Context, id string) error
+}
+
+// delayedList is the state shared by the delayed store implementations. Jobs
+// are kept sorted by run-at time, and jobs with the same run-at time are kept
+// in the order they were scheduled. It is not safe for concurrent use.
+type delayedList struct {
+	Jobs []DelayedJob `json:"jobs"`
+}
+
+func (l *delayedList) schedule(payload []byte, runAt time.Time) string {
+	id := uuid.New().String()
+
+	i := sort.Search(len(l.Jobs), func(i int) bool { return l.Jobs[i].RunAt.After(runAt) })
+	l.Jobs = append(l.Jobs, DelayedJob{})
+	copy(l.Jobs[i+1:], l.Jobs[i:])
+	l.Jobs[i] = DelayedJob{ID: id, Payload: payload, RunAt: runAt}
+
+	return id
+}
+
+func (l *delayedList) due(now time.Time) (DelayedJob, bool) {
+	if len(l.Jobs) == 0 || l.Jobs[0].RunAt.After(now) {
+		return DelayedJob{}, false
+	}
+
+	return l.Jobs[0], true
+}
+
+func (l *delayedList) remove(id string) error {
+	for i, job := range l.Jobs {
+		if job.ID == id {
+			l.Jobs = append(l.Jobs[:i], l.Jobs[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrJobNotFound
+}
+
+// clone returns a copy of the list, sharing job payloads, which are never
+// modified in place.
+func (l *delayedList) clone() *delayedList {
+	return &delayedList{Jobs: append([]DelayedJob(nil), l.Jobs...)}
+}
@@ -0,0 +1,100 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelayedStores(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testDelayedStore(t, func() DelayedStore {
+			return NewMemoryDelayedStore()
+		})
+	})
+
+	t.Run("file", func(t *testing.T) {
+		testDelayedStore(t, func() DelayedStore {
+			store, err := NewFileDelayedStore(filepath.Join(t.TempDir(), "delayed.json"))
+			require.Nil(t, err)
+			return store
+		})
+	})
+}
+
+func testDelayedStore(t *testing.T, makeStore func() DelayedStore) {
+	t.Run("run-at order", func(t *testing.T) {
+		var (
+			store = makeStore()
+			ctx   = context.Background()
+			now   = time.Now()
+		)
+
+		id1, err := store.Schedule(ctx, []byte("a"), now.Add(time.Hour*2))
+		require.Nil(t, err)
+		id2, err := store.Schedule(ctx, []byte("b"), now.Add(time.Hour))
+		require.Nil(t, err)
+		id3, err := store.Schedule(ctx, []byte("c"), now.Add(time.Hour))
+		require.Nil(t, err)
+
+		_, ok, err := store.Due(ctx, now.Add(time.Hour-time.Second))
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		for _, id := range []string{id2, id3, id1} {
+			job, ok, err := store.Due(ctx, now.Add(time.Hour*2))
+			require.Nil(t, err)
+			require.True(t, ok)
+			assert.Equal(t, id, job.ID)
+			require.Nil(t, store.Remove(ctx, job.ID))
+		}
+
+		_, ok, err = store.Due(ctx, now.Add(time.Hour*2))
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		var (
+			store = makeStore()
+			ctx   = context.Background()
+			now   = time.Now()
+		)
+
+		id, err := store.Schedule(ctx, []byte("a"), now)
+		require.Nil(t, err)
+		require.Nil(t, store.Remove(ctx, id))
+		assert.Equal(t, ErrJobNotFound, store.Remove(ctx, id))
+
+		_, ok, err := store.Due(ctx, now)
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestFileDelayedStorePersistence(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "delayed.json")
+		ctx  = context.Background()
+		now  = time.Now().UTC().Truncate(time.Second)
+	)
+
+	store, err := NewFileDelayedStore(path)
+	require.Nil(t, err)
+
+	id, err := store.Schedule(ctx, []byte("a"), now.Add(time.Hour))
+	require.Nil(t, err)
+
+	// Reopen the store as if the process had restarted
+	store, err = NewFileDelayedStore(path)
+	require.Nil(t, err)
+
+	job, ok, err := store.Due(ctx, now.Add(time.Hour))
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, DelayedJob{ID: id, Payload: []byte("a"), RunAt: now.Add(time.Hour)}, job)
+}
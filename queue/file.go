@@ -0,0 +1,269 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/workerbase/internal/atomicfile"
+)
+
+// FileQueue is a Queue persisted to a single file, which is atomically rewritten
+// after every change. Jobs that were dequeued but not acknowledged before the
+// process exited are redelivered once their visibility timeout elapses. A file
+// must not be shared by more than one FileQueue.
+type FileQueue struct {
+	path  string
+	clock glock.Clock
+	mutex sync.Mutex
+	jobs  *jobList
+}
+
+var _ Queue = &FileQueue{}
+
+// NewFileQueue creates a FileQueue persisted at the given path, loading any jobs
+// previously persisted there.
+func NewFileQueue(path string) (*FileQueue, error) {
+	return newFileQueue(path, glock.NewRealClock())
+}
+
+func newFileQueue(path string, clock glock.Clock) (*FileQueue, error) {
+	jobs := &jobList{}
+	if err := readJSONFile(path, jobs); err != nil {
+		return nil, err
+	}
+
+	return &FileQueue{
+		path:  path,
+		clock: clock,
+		jobs:  jobs,
+	}, nil
+}
+
+func (q *FileQueue) Enqueue(ctx context.Context, payload []byte) (id string, err error) {
+	err = q.update(func(jobs *jobList) (bool, error) {
+		id = jobs.enqueue(payload, q.clock.Now())
+		return true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (q *FileQueue) Dequeue(ctx context.Context, visibilityTimeout time.Duration) (job Job, ok bool, err error) {
+	err = q.update(func(jobs *jobList) (bool, error) {
+		// Polling an empty queue does not rewrite the file
+		job, ok = jobs.dequeue(q.clock.Now(), visibilityTimeout)
+		return ok, nil
+	})
+	if err != nil {
+		return Job{}, false, err
+	}
+
+	return job, ok, nil
+}
+
+func (q *FileQueue) Ack(ctx context.Context, receipt string) error {
+	return q.update(func(jobs *jobList) (bool, error) {
+		return true, jobs.ack(receipt)
+	})
+}
+
+func (q *FileQueue) Nack(ctx context.Context, receipt string, delay time.Duration, cause error) error {
+	return q.update(func(jobs *jobList) (bool, error) {
+		now := q.clock.Now()
+		return true, jobs.nack(receipt, now, now.Add(delay), cause)
+	})
+}
+
+// update applies the given function to a copy of the queue's jobs and persists
+// the result, unless the function reports that it made no change. The queue is
+// unchanged if the function or the write fails.
+func (q *FileQueue) update(f func(jobs *jobList) (bool, error)) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	jobs := q.jobs.clone()
+	changed, err := f(jobs)
+	if err != nil || !changed {
+		return err
+	}
+
+	if err := writeJSONFile(q.path, jobs); err != nil {
+		return err
+	}
+
+	q.jobs = jobs
+	return nil
+}
+
+// FileDelayedStore is a DelayedStore persisted to a single file, which is
+// atomically rewritten after every change. A file must not be shared by more
+// than one FileDelayedStore.
+type FileDelayedStore struct {
+	path  string
+	mutex sync.Mutex
+	jobs  *delayedList
+}
+
+var _ DelayedStore = &FileDelayedStore{}
+
+// NewFileDelayedStore creates a FileDelayedStore persisted at the given path,
+// loading any jobs previously persisted there.
+func NewFileDelayedStore(path string) (*FileDelayedStore, error) {
+	jobs := &delayedList{}
+	if err := readJSONFile(path, jobs); err != nil {
+		return nil, err
+	}
+
+	return &FileDelayedStore{
+		path: path,
+		jobs: jobs,
+	}, nil
+}
+
+func (s *FileDelayedStore) Schedule(ctx context.Context, payload []byte, runAt time.Time) (id string, err error) {
+	err = s.update(func(jobs *delayedList) error {
+		id = jobs.schedule(payload, runAt)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *FileDelayedStore) Due(ctx context.Context, now time.Time) (DelayedJob, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	job, ok := s.jobs.due(now)
+	return job, ok, nil
+}
+
+func (s *FileDelayedStore) Remove(ctx context.Context, id string) error {
+	return s.update(func(jobs *delayedList) error {
+		return jobs.remove(id)
+	})
+}
+
+// update applies the given function to a copy of the store's jobs and persists
+// the result. The store is unchanged if the function or the write fails.
+func (s *FileDelayedStore) update(f func(jobs *delayedList) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	jobs := s.jobs.clone()
+	if err := f(jobs); err != nil {
+		return err
+	}
+
+	if err := writeJSONFile(s.path, jobs); err != nil {
+		return err
+	}
+
+	s.jobs = jobs
+	return nil
+}
+
+// FileDeadLetterStore is a DeadLetterStore persisted to a single file, which is
+// atomically rewritten after every change. A file must not be shared by more
+// than one FileDeadLetterStore.
+type FileDeadLetterStore struct {
+	path  string
+	mutex sync.Mutex
+	jobs  *deadList
+}
+
+var _ DeadLetterStore = &FileDeadLetterStore{}
+
+// NewFileDeadLetterStore creates a FileDeadLetterStore persisted at the given
+// path, loading any jobs previously persisted there.
+func NewFileDeadLetterStore(path string) (*FileDeadLetterStore, error) {
+	jobs := &deadList{}
+	if err := readJSONFile(path, jobs); err != nil {
+		return nil, err
+	}
+
+	return &FileDeadLetterStore{
+		path: path,
+		jobs: jobs,
+	}, nil
+}
+
+func (s *FileDeadLetterStore) Add(ctx context.Context, job DeadJob) error {
+	return s.update(func(jobs *deadList) error {
+		jobs.add(job)
+		return nil
+	})
+}
+
+func (s *FileDeadLetterStore) List(ctx context.Context) ([]DeadJob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.list(), nil
+}
+
+func (s *FileDeadLetterStore) Get(ctx context.Context, id string) (DeadJob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.get(id)
+}
+
+func (s *FileDeadLetterStore) Remove(ctx context.Context, id string) error {
+	return s.update(func(jobs *deadList) error {
+		return jobs.remove(id)
+	})
+}
+
+// update applies the given function to a copy of the store's jobs and persists
+// the result. The store is unchanged if the function or the write fails.
+func (s *FileDeadLetterStore) update(f func(jobs *deadList) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	jobs := s.jobs.clone()
+	if err := f(jobs); err != nil {
+		return err
+	}
+
+	if err := writeJSONFile(s.path, jobs); err != nil {
+		return err
+	}
+
+	s.jobs = jobs
+	return nil
+}
+
+// readJSONFile decodes the contents of the given path into v. A missing file
+// leaves v unchanged.
+func readJSONFile(path string, v interface{}) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return json.Unmarshal(contents, v)
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	contents, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(path, contents, 0o644)
+}
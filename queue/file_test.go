@@ -0,0 +1,108 @@
+package queue
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileQueuePersistence(t *testing.T) {
+	var (
+		clock = glock.NewMockClock()
+		path  = filepath.Join(t.TempDir(), "queue.json")
+		ctx   = context.Background()
+	)
+
+	queue, err := newFileQueue(path, clock)
+	require.Nil(t, err)
+
+	id1, err := queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+	id2, err := queue.Enqueue(ctx, []byte("b"))
+	require.Nil(t, err)
+	_, _, err = queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+
+	// Reopen the queue as if the process had restarted
+	queue, err = newFileQueue(path, clock)
+	require.Nil(t, err)
+
+	job, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, id2, job.ID)
+
+	// The job in flight before the restart is redelivered
+	clock.Advance(time.Minute)
+	job, ok, err = queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, id1, job.ID)
+	assert.Equal(t, []byte("a"), job.Payload)
+	assert.Equal(t, 2, job.Attempts)
+}
+
+func TestFileQueueEmptyDequeueDoesNotWrite(t *testing.T) {
+	var (
+		clock = glock.NewMockClock()
+		path  = filepath.Join(t.TempDir(), "queue.json")
+		ctx   = context.Background()
+	)
+
+	queue, err := newFileQueue(path, clock)
+	require.Nil(t, err)
+
+	_, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileQueueWriteError(t *testing.T) {
+	var (
+		clock = glock.NewMockClock()
+		dir   = t.TempDir()
+		path  = filepath.Join(dir, "queue.json")
+		ctx   = context.Background()
+	)
+
+	queue, err := newFileQueue(path, clock)
+	require.Nil(t, err)
+
+	_, err = queue.Enqueue(ctx, []byte("a"))
+	require.Nil(t, err)
+
+	// Replace the directory with a file so that writes fail
+	require.Nil(t, os.RemoveAll(dir))
+	require.Nil(t, ioutil.WriteFile(dir, nil, 0o644))
+	defer os.Remove(dir)
+
+	_, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.NotNil(t, err)
+	assert.False(t, ok)
+
+	// The failed dequeue did not hide the job
+	require.Nil(t, os.Remove(dir))
+	require.Nil(t, os.Mkdir(dir, 0o755))
+
+	_, ok, err = queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestNewFileQueueInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte("not json"), 0o644))
+
+	_, err := NewFileQueue(path)
+	assert.NotNil(t, err)
+}
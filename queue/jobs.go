@@ -0,0 +1,109 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jobList is the state shared by the queue implementations. It is not safe for
+// concurrent use.
+type jobList struct {
+	Jobs []*jobState `json:"jobs"`
+}
+
+type jobState struct {
+	Job       Job       `json:"job"`
+	VisibleAt time.Time `json:"visible_at"`
+}
+
+func (l *jobList) enqueue(payload []byte, now time.Time) string {
+	id := uuid.New().String()
+
+	l.Jobs = append(l.Jobs, &jobState{
+		Job: Job{
+			ID:         id,
+			Payload:    payload,
+			EnqueuedAt: now,
+		},
+		VisibleAt: now,
+	})
+
+	return id
+}
+
+// dequeue returns the job that has been visible the longest at the given time
+// and hides it until the visibility timeout elapses. Jobs that became visible
+// at the same time are returned in the order they were enqueued, and a job that
+// is returned to the queue is placed behind the jobs that are already visible.
+func (l *jobList) dequeue(now time.Time, visibilityTimeout time.Duration) (Job, bool) {
+	var next *jobState
+	for _, state := range l.Jobs {
+		if !state.VisibleAt.After(now) && (next == nil || state.VisibleAt.Before(next.VisibleAt)) {
+			next = state
+		}
+	}
+
+	if next == nil {
+		return Job{}, false
+	}
+
+	next.Job.Attempts++
+	next.Job.Receipt = uuid.New().String()
+	next.VisibleAt = now.Add(visibilityTimeout)
+
+	job := next.Job
+	job.Errors = append([]JobError(nil), next.Job.Errors...)
+	return job, true
+}
+
+// ack removes the job with the given receipt.
+func (l *jobList) ack(receipt string) error {
+	for i, state := range l.Jobs {
+		if receipt != "" && state.Job.Receipt == receipt {
+			l.Jobs = append(l.Jobs[:i], l.Jobs[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrJobNotFound
+}
+
+// nack returns the job with the given receipt to the queue to become visible at
+// the given time. The job is moved to the back of the list so that it follows any
+// job that became visible at the same time.
+func (l *jobList) nack(receipt string, now, visibleAt time.Time, cause error) error {
+	for i, state := range l.Jobs {
+		if receipt == "" || state.Job.Receipt != receipt {
+			continue
+		}
+
+		if cause != nil {
+			state.Job.Errors = append(state.Job.Errors, JobError{
+				Attempt:  state.Job.Attempts,
+				Message:  cause.Error(),
+				FailedAt: now,
+			})
+		}
+		state.Job.Receipt = ""
+		state.VisibleAt = visibleAt
+
+		l.Jobs = append(append(l.Jobs[:i:i], l.Jobs[i+1:]...), state)
+		return nil
+	}
+
+	return ErrJobNotFound
+}
+
+// clone returns a deep copy of the list, excluding job payloads, which are
+// never modified in place.
+func (l *jobList) clone() *jobList {
+	jobs := make([]*jobState, 0, len(l.Jobs))
+	for _, state := range l.Jobs {
+		copied := *state
+		copied.Job.Errors = append([]JobError(nil), state.Job.Errors...)
+		jobs = append(jobs, &copied)
+	}
+
+	return &jobList{Jobs: jobs}
+}
@@ -0,0 +1,139 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// MemoryQueue is a Queue held in memory. Its jobs are lost when the process exits.
+type MemoryQueue struct {
+	clock glock.Clock
+	mutex sync.Mutex
+	jobs  *jobList
+}
+
+var _ Queue = &MemoryQueue{}
+
+// NewMemoryQueue creates an empty MemoryQueue.
+func NewMemoryQueue() *MemoryQueue {
+	return newMemoryQueue(glock.NewRealClock())
+}
+
+func newMemoryQueue(clock glock.Clock) *MemoryQueue {
+	return &MemoryQueue{
+		clock: clock,
+		jobs:  &jobList{},
+	}
+}
+
+func (q *MemoryQueue) Enqueue(ctx context.Context, payload []byte) (string, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.jobs.enqueue(payload, q.clock.Now()), nil
+}
+
+func (q *MemoryQueue) Dequeue(ctx context.Context, visibilityTimeout time.Duration) (Job, bool, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	job, ok := q.jobs.dequeue(q.clock.Now(), visibilityTimeout)
+	return job, ok, nil
+}
+
+func (q *MemoryQueue) Ack(ctx context.Context, receipt string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.jobs.ack(receipt)
+}
+
+func (q *MemoryQueue) Nack(ctx context.Context, receipt string, delay time.Duration, cause error) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := q.clock.Now()
+	return q.jobs.nack(receipt, now, now.Add(delay), cause)
+}
+
+// MemoryDelayedStore is a DelayedStore held in memory. Its jobs are lost when
+// the process exits.
+type MemoryDelayedStore struct {
+	mutex sync.Mutex
+	jobs  *delayedList
+}
+
+var _ DelayedStore = &MemoryDelayedStore{}
+
+// NewMemoryDelayedStore creates an empty MemoryDelayedStore.
+func NewMemoryDelayedStore() *MemoryDelayedStore {
+	return &MemoryDelayedStore{jobs: &delayedList{}}
+}
+
+func (s *MemoryDelayedStore) Schedule(ctx context.Context, payload []byte, runAt time.Time) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.schedule(payload, runAt), nil
+}
+
+func (s *MemoryDelayedStore) Due(ctx context.Context, now time.Time) (DelayedJob, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	job, ok := s.jobs.due(now)
+	return job, ok, nil
+}
+
+func (s *MemoryDelayedStore) Remove(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.remove(id)
+}
+
+// MemoryDeadLetterStore is a DeadLetterStore held in memory. Its jobs are lost
+// when the process exits.
+type MemoryDeadLetterStore struct {
+	mutex sync.Mutex
+	jobs  *deadList
+}
+
+var _ DeadLetterStore = &MemoryDeadLetterStore{}
+
+// NewMemoryDeadLetterStore creates an empty MemoryDeadLetterStore.
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{jobs: &deadList{}}
+}
+
+func (s *MemoryDeadLetterStore) Add(ctx context.Context, job DeadJob) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.jobs.add(job)
+	return nil
+}
+
+func (s *MemoryDeadLetterStore) List(ctx context.Context) ([]DeadJob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.list(), nil
+}
+
+func (s *MemoryDeadLetterStore) Get(ctx context.Context, id string) (DeadJob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.get(id)
+}
+
+func (s *MemoryDeadLetterStore) Remove(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.remove(id)
+}
@@ -0,0 +1,63 @@
+package queue
+
+import "time"
+
+type (
+	options struct {
+		visibilityTimeout time.Duration
+		backoff           Backoff
+		maxAttempts       int
+		deadLetters       DeadLetterStore
+	}
+
+	// ConfigFunc is a function used to configure an instance of a Consumer.
+	ConfigFunc func(*options)
+)
+
+// WithVisibilityTimeout sets the duration for which a dequeued job is hidden
+// from other consumers. A job that is neither acknowledged nor returned to the
+// queue within this duration is redelivered. The default is one minute.
+func WithVisibilityTimeout(timeout time.Duration) ConfigFunc {
+	return func(o *options) { o.visibilityTimeout = timeout }
+}
+
+// WithNackDelay sets a constant delay before a job whose handler failed is
+// redelivered. This option replaces any backoff set by WithBackoff.
+func WithNackDelay(delay time.Duration) ConfigFunc {
+	return func(o *options) { o.backoff = constantBackoff(delay) }
+}
+
+// WithBackoff sets the function that determines the delay before a job whose
+// handler failed is redelivered. This option replaces any delay set by
+// WithNackDelay. The default backoff starts at one second and doubles with each
+// failed attempt, up to five minutes.
+func WithBackoff(backoff Backoff) ConfigFunc {
+	return func(o *options) { o.backoff = backoff }
+}
+
+// WithMaxAttempts sets the number of times a job is handled before the consumer
+// gives up on it. A job that fails its last attempt is moved to the dead-letter
+// store (see WithDeadLetterStore), or discarded if no store is set. By default,
+// a job is retried until it succeeds.
+func WithMaxAttempts(maxAttempts int) ConfigFunc {
+	return func(o *options) { o.maxAttempts = maxAttempts }
+}
+
+// WithDeadLetterStore sets the store that receives jobs that exhausted their
+// attempts (see WithMaxAttempts).
+func WithDeadLetterStore(store DeadLetterStore) ConfigFunc {
+	return func(o *options) { o.deadLetters = store }
+}
+
+func getOptions(configs []ConfigFunc) *options {
+	options := &options{
+		visibilityTimeout: time.Minute,
+		backoff:           ExponentialBackoff(time.Second, 2, time.Minute*5),
+	}
+
+	for _, f := range configs {
+		f(options)
+	}
+
+	return options
+}
@@ -0,0 +1,209 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Lane is a queue of jobs that share a priority within a PriorityQueue.
+type Lane struct {
+	// Queue holds the jobs of the lane. Jobs are enqueued onto this queue
+	// directly to give them the lane's priority.
+	Queue Queue
+
+	// Weight is the relative share of dequeues given to the lane when weighted
+	// fairness is enabled (see WithWeightedFairness). A non-positive weight is
+	// treated as one.
+	Weight int
+}
+
+type (
+	priorityOptions struct {
+		weighted        bool
+		starvationLimit int
+	}
+
+	// PriorityConfigFunc is a function used to configure an instance of a
+	// PriorityQueue.
+	PriorityConfigFunc func(*priorityOptions)
+)
+
+// WithWeightedFairness causes the queue to distribute dequeues between lanes in
+// proportion to their weights, rather than always preferring the highest lane.
+// If the lane whose turn it is has no visible jobs, the remaining lanes are tried
+// in priority order.
+func WithWeightedFairness() PriorityConfigFunc {
+	return func(o *priorityOptions) { o.weighted = true }
+}
+
+// WithStarvationLimit bounds the number of times a lane can be passed over in
+// favor of a higher lane. Once a lane has been passed over the given number of
+// times, the next dequeue tries that lane first. By default, there is no limit.
+func WithStarvationLimit(limit int) PriorityConfigFunc {
+	return func(o *priorityOptions) { o.starvationLimit = limit }
+}
+
+// PriorityQueue is a Queue composed of lanes ordered from highest to lowest
+// priority. A dequeue returns a job from the highest lane with a visible job,
+// subject to the configured fairness options. Jobs are acknowledged and returned
+// to the lane from which they were dequeued.
+type PriorityQueue struct {
+	lanes           []Lane
+	weighted        bool
+	starvationLimit int
+	mutex           sync.Mutex
+	current         []int
+	passedOver      []int
+}
+
+var _ Queue = &PriorityQueue{}
+
+// NewPriorityQueue creates a PriorityQueue from the given lanes, ordered from
+// highest to lowest priority. An error is returned if no lanes are given or a
+// lane has no queue.
+func NewPriorityQueue(lanes []Lane, configs ...PriorityConfigFunc) (*PriorityQueue, error) {
+	if len(lanes) == 0 {
+		return nil, fmt.Errorf("priority queue requires at least one lane")
+	}
+
+	for i, lane := range lanes {
+		if lane.Queue == nil {
+			return nil, fmt.Errorf("priority queue lane %d has no queue", i)
+		}
+	}
+
+	options := &priorityOptions{}
+	for _, f := range configs {
+		f(options)
+	}
+
+	return &PriorityQueue{
+		lanes:           lanes,
+		weighted:        options.weighted,
+		starvationLimit: options.starvationLimit,
+		current:         make([]int, len(lanes)),
+		passedOver:      make([]int, len(lanes)),
+	}, nil
+}
+
+// Enqueue adds a job to the lowest priority lane. Use EnqueuePriority to give
+// a job a higher priority.
+func (q *PriorityQueue) Enqueue(ctx context.Context, payload []byte) (string, error) {
+	return q.EnqueuePriority(ctx, len(q.lanes)-1, payload)
+}
+
+// EnqueuePriority adds a job to the lane with the given index, where zero is the
+// highest priority lane. An error is returned if there is no such lane.
+func (q *PriorityQueue) EnqueuePriority(ctx context.Context, lane int, payload []byte) (string, error) {
+	if lane < 0 || lane >= len(q.lanes) {
+		return "", fmt.Errorf("priority queue has no lane %d", lane)
+	}
+
+	return q.lanes[lane].Queue.Enqueue(ctx, payload)
+}
+
+func (q *PriorityQueue) Dequeue(ctx context.Context, visibilityTimeout time.Duration) (Job, bool, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, i := range q.laneOrder() {
+		job, ok, err := q.lanes[i].Queue.Dequeue(ctx, visibilityTimeout)
+		if err != nil {
+			return Job{}, false, err
+		}
+
+		if !ok {
+			// An empty lane is not being starved
+			q.passedOver[i] = 0
+			continue
+		}
+
+		q.passedOver[i] = 0
+		for j := i + 1; j < len(q.lanes); j++ {
+			q.passedOver[j]++
+		}
+
+		return job, true, nil
+	}
+
+	return Job{}, false, nil
+}
+
+func (q *PriorityQueue) Ack(ctx context.Context, receipt string) error {
+	return q.forLane(func(queue Queue) error { return queue.Ack(ctx, receipt) })
+}
+
+func (q *PriorityQueue) Nack(ctx context.Context, receipt string, delay time.Duration, cause error) error {
+	return q.forLane(func(queue Queue) error { return queue.Nack(ctx, receipt, delay, cause) })
+}
+
+// laneOrder returns the indexes of the lanes in the order they should be tried
+// by the next dequeue. Starved lanes come first, followed by the lane chosen by
+// weighted fairness, followed by the remaining lanes in priority order.
+func (q *PriorityQueue) laneOrder() []int {
+	order := make([]int, 0, len(q.lanes))
+	seen := make([]bool, len(q.lanes))
+	add := func(i int) {
+		if !seen[i] {
+			seen[i] = true
+			order = append(order, i)
+		}
+	}
+
+	if q.starvationLimit > 0 {
+		for i := range q.lanes {
+			if q.passedOver[i] >= q.starvationLimit {
+				add(i)
+			}
+		}
+	}
+
+	if q.weighted {
+		add(q.nextWeightedLane())
+	}
+
+	for i := range q.lanes {
+		add(i)
+	}
+
+	return order
+}
+
+// nextWeightedLane selects a lane by smooth weighted round-robin, so that over
+// any window each lane is chosen in proportion to its weight and the choices
+// are interleaved rather than bunched together.
+func (q *PriorityQueue) nextWeightedLane() int {
+	best, total := 0, 0
+	for i, lane := range q.lanes {
+		weight := lane.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+
+		q.current[i] += weight
+		total += weight
+
+		if q.current[i] > q.current[best] {
+			best = i
+		}
+	}
+
+	q.current[best] -= total
+	return best
+}
+
+// forLane invokes the given function with each lane's queue until it returns
+// something other than ErrJobNotFound. Receipts are unique across lanes, so at
+// most one lane holds the job with a given receipt.
+func (q *PriorityQueue) forLane(f func(queue Queue) error) error {
+	for _, lane := range q.lanes {
+		if err := f(lane.Queue); !errors.Is(err, ErrJobNotFound) {
+			return err
+		}
+	}
+
+	return ErrJobNotFound
+}
@@ -0,0 +1,116 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityQueueStrict(t *testing.T) {
+	var (
+		clock  = glock.NewMockClock()
+		urgent = newMemoryQueue(clock)
+		bulk   = newMemoryQueue(clock)
+		queue  = makePriorityQueue(t, []Lane{{Queue: urgent}, {Queue: bulk}})
+		ctx    = context.Background()
+	)
+
+	enqueueAll(t, bulk, "b1", "b2")
+	enqueueAll(t, urgent, "u1", "u2")
+
+	assert.Equal(t, []string{"u1", "u2", "b1", "b2"}, dequeueAll(t, queue, 5))
+
+	// Enqueue without a lane targets the lowest priority lane
+	_, err := queue.Enqueue(ctx, []byte("b3"))
+	require.Nil(t, err)
+	assert.Equal(t, []string{"b3"}, dequeueAll(t, bulk, 1))
+
+	_, err = queue.EnqueuePriority(ctx, 0, []byte("u3"))
+	require.Nil(t, err)
+	assert.Equal(t, []string{"u3"}, dequeueAll(t, urgent, 1))
+
+	for _, lane := range []int{-1, 2} {
+		_, err = queue.EnqueuePriority(ctx, lane, []byte("x"))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestPriorityQueueInvalidLanes(t *testing.T) {
+	_, err := NewPriorityQueue(nil)
+	assert.EqualError(t, err, "priority queue requires at least one lane")
+
+	_, err = NewPriorityQueue([]Lane{{Queue: NewMemoryQueue()}, {}})
+	assert.EqualError(t, err, "priority queue lane 1 has no queue")
+}
+
+func TestPriorityQueueWeightedFairness(t *testing.T) {
+	var (
+		clock  = glock.NewMockClock()
+		urgent = newMemoryQueue(clock)
+		bulk   = newMemoryQueue(clock)
+		queue  = makePriorityQueue(t, []Lane{
+			{Queue: urgent, Weight: 2},
+			{Queue: bulk, Weight: 1},
+		}, WithWeightedFairness())
+	)
+
+	enqueueAll(t, urgent, "u1", "u2", "u3", "u4", "u5")
+	enqueueAll(t, bulk, "b1", "b2")
+
+	// Bulk work makes progress in proportion to its weight, and urgent work
+	// takes the remaining turns once the bulk lane is empty.
+	assert.Equal(t, []string{"u1", "b1", "u2", "u3", "b2", "u4", "u5"}, dequeueAll(t, queue, 8))
+}
+
+func TestPriorityQueueStarvationLimit(t *testing.T) {
+	var (
+		clock  = glock.NewMockClock()
+		urgent = newMemoryQueue(clock)
+		normal = newMemoryQueue(clock)
+		bulk   = newMemoryQueue(clock)
+		queue  = makePriorityQueue(t, []Lane{{Queue: urgent}, {Queue: normal}, {Queue: bulk}}, WithStarvationLimit(2))
+	)
+
+	enqueueAll(t, urgent, "u1", "u2", "u3", "u4", "u5")
+	enqueueAll(t, normal, "n1")
+	enqueueAll(t, bulk, "b1", "b2")
+
+	assert.Equal(t, []string{"u1", "u2", "n1", "b1", "u3", "u4", "b2", "u5"}, dequeueAll(t, queue, 9))
+}
+
+func TestPriorityQueueAckNack(t *testing.T) {
+	var (
+		clock  = glock.NewMockClock()
+		urgent = newMemoryQueue(clock)
+		bulk   = newMemoryQueue(clock)
+		queue  = makePriorityQueue(t, []Lane{{Queue: urgent}, {Queue: bulk}})
+		ctx    = context.Background()
+	)
+
+	enqueueAll(t, urgent, "u1")
+	enqueueAll(t, bulk, "b1")
+
+	job1, _, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	job2, _, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+
+	require.Nil(t, queue.Ack(ctx, job1.Receipt))
+	require.Nil(t, queue.Nack(ctx, job2.Receipt, 0, errors.New("oops")))
+	assert.Equal(t, ErrJobNotFound, queue.Ack(ctx, "unknown"))
+
+	// The nacked job returns to its own lane
+	assert.Equal(t, []string{"b1"}, dequeueAll(t, bulk, 1))
+	assert.Empty(t, dequeueAll(t, urgent, 1))
+}
+
+func makePriorityQueue(t *testing.T, lanes []Lane, configs ...PriorityConfigFunc) *PriorityQueue {
+	queue, err := NewPriorityQueue(lanes, configs...)
+	require.Nil(t, err)
+	return queue
+}
@@ -0,0 +1,69 @@
+// Package queue provides job queues and a worker spec that consumes them.
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Job is a unit of work held by a Queue.
+type Job struct {
+	// ID uniquely identifies the job within its queue.
+	ID string `json:"id"`
+
+	// Payload is the opaque content of the job.
+	Payload []byte `json:"payload"`
+
+	// Attempts is the number of times the job has been dequeued, including
+	// the current delivery.
+	Attempts int `json:"attempts"`
+
+	// EnqueuedAt is the time at which the job was enqueued.
+	EnqueuedAt time.Time `json:"enqueued_at"`
+
+	// Errors is the history of failed attempts to handle the job, oldest first.
+	Errors []JobError `json:"errors,omitempty"`
+
+	// Receipt identifies the current delivery of the job. It is set by Dequeue
+	// and is passed to Ack or Nack. A receipt is no longer accepted once the job
+	// has been redelivered, so a consumer whose visibility timeout lapsed cannot
+	// acknowledge or return a job that another consumer now holds.
+	Receipt string `json:"receipt,omitempty"`
+}
+
+// JobError records a failed attempt to handle a job.
+type JobError struct {
+	// Attempt is the attempt number that failed.
+	Attempt int `json:"attempt"`
+
+	// Message is the error returned by the handler.
+	Message string `json:"message"`
+
+	// FailedAt is the time at which the attempt failed.
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// Queue is a queue of jobs with at-least-once delivery. A dequeued job is hidden
+// from other consumers for a visibility timeout, after which it is redelivered
+// unless it has been acknowledged.
+type Queue interface {
+	// Enqueue adds a job with the given payload to the queue and returns its ID.
+	Enqueue(ctx context.Context, payload []byte) (string, error)
+
+	// Dequeue returns the job that has been visible the longest and hides it
+	// for the given visibility timeout. If no job is visible, false is returned.
+	Dequeue(ctx context.Context, visibilityTimeout time.Duration) (Job, bool, error)
+
+	// Ack removes the dequeued job with the given receipt from the queue.
+	Ack(ctx context.Context, receipt string) error
+
+	// Nack returns the dequeued job with the given receipt to the queue, to be
+	// redelivered after the given delay. The given error is appended to the job's
+	// error history.
+	Nack(ctx context.Context, receipt string, delay time.Duration, cause error) error
+}
+
+// ErrJobNotFound is returned when referring to a job that is not in a queue or
+// store, or by a receipt that is no longer current.
+var ErrJobNotFound = errors.New("job not found")
@@ -0,0 +1,241 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueues(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testQueue(t, func(clock glock.Clock) Queue {
+			return newMemoryQueue(clock)
+		})
+	})
+
+	t.Run("file", func(t *testing.T) {
+		testQueue(t, func(clock glock.Clock) Queue {
+			queue, err := newFileQueue(filepath.Join(t.TempDir(), "queue.json"), clock)
+			require.Nil(t, err)
+			return queue
+		})
+	})
+}
+
+func testQueue(t *testing.T, makeQueue func(clock glock.Clock) Queue) {
+	t.Run("fifo", func(t *testing.T) {
+		var (
+			clock = glock.NewMockClock()
+			queue = makeQueue(clock)
+			ctx   = context.Background()
+		)
+
+		id1, err := queue.Enqueue(ctx, []byte("a"))
+		require.Nil(t, err)
+		id2, err := queue.Enqueue(ctx, []byte("b"))
+		require.Nil(t, err)
+
+		job, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.NotEmpty(t, job.Receipt)
+		assert.Equal(t, Job{ID: id1, Payload: []byte("a"), Attempts: 1, EnqueuedAt: clock.Now(), Receipt: job.Receipt}, job)
+
+		job, ok, err = queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, id2, job.ID)
+
+		_, ok, err = queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("visibility timeout", func(t *testing.T) {
+		var (
+			clock = glock.NewMockClock()
+			queue = makeQueue(clock)
+			ctx   = context.Background()
+		)
+
+		id, err := queue.Enqueue(ctx, []byte("a"))
+		require.Nil(t, err)
+
+		_, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		clock.Advance(time.Second * 59)
+		_, ok, err = queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		// Redelivered once the visibility timeout elapses
+		clock.Advance(time.Second)
+		job, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, id, job.ID)
+		assert.Equal(t, 2, job.Attempts)
+	})
+
+	t.Run("ack", func(t *testing.T) {
+		var (
+			clock = glock.NewMockClock()
+			queue = makeQueue(clock)
+			ctx   = context.Background()
+		)
+
+		id, err := queue.Enqueue(ctx, []byte("a"))
+		require.Nil(t, err)
+		job, _, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+
+		assert.Equal(t, ErrJobNotFound, queue.Ack(ctx, id))
+		require.Nil(t, queue.Ack(ctx, job.Receipt))
+		assert.Equal(t, ErrJobNotFound, queue.Ack(ctx, job.Receipt))
+
+		clock.Advance(time.Minute)
+		_, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("nack behind visible jobs", func(t *testing.T) {
+		var (
+			clock = glock.NewMockClock()
+			queue = makeQueue(clock)
+			ctx   = context.Background()
+		)
+
+		id1, err := queue.Enqueue(ctx, []byte("a"))
+		require.Nil(t, err)
+		id2, err := queue.Enqueue(ctx, []byte("b"))
+		require.Nil(t, err)
+
+		job, _, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.Equal(t, id1, job.ID)
+		require.Nil(t, queue.Nack(ctx, job.Receipt, 0, errors.New("oops")))
+
+		for _, id := range []string{id2, id1} {
+			job, ok, err := queue.Dequeue(ctx, time.Minute)
+			require.Nil(t, err)
+			require.True(t, ok)
+			assert.Equal(t, id, job.ID)
+		}
+	})
+
+	t.Run("nack", func(t *testing.T) {
+		var (
+			clock = glock.NewMockClock()
+			queue = makeQueue(clock)
+			ctx   = context.Background()
+		)
+
+		id, err := queue.Enqueue(ctx, []byte("a"))
+		require.Nil(t, err)
+		job, _, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+
+		failedAt := clock.Now()
+		require.Nil(t, queue.Nack(ctx, job.Receipt, time.Second*10, errors.New("oops")))
+		assert.Equal(t, ErrJobNotFound, queue.Nack(ctx, job.Receipt, 0, nil))
+		assert.Equal(t, ErrJobNotFound, queue.Ack(ctx, job.Receipt))
+
+		_, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		clock.Advance(time.Second * 10)
+		job, ok, err = queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, id, job.ID)
+		assert.Equal(t, 2, job.Attempts)
+		assert.Equal(t, []JobError{{Attempt: 1, Message: "oops", FailedAt: failedAt}}, job.Errors)
+	})
+
+	t.Run("stale ack", func(t *testing.T) {
+		var (
+			clock = glock.NewMockClock()
+			queue = makeQueue(clock)
+			ctx   = context.Background()
+		)
+
+		_, err := queue.Enqueue(ctx, []byte("a"))
+		require.Nil(t, err)
+		stale, _, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+
+		clock.Advance(time.Minute)
+		current, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		// The lapsed lease does not remove the job from its current holder
+		assert.Equal(t, ErrJobNotFound, queue.Ack(ctx, stale.Receipt))
+		require.Nil(t, queue.Ack(ctx, current.Receipt))
+	})
+
+	t.Run("stale nack", func(t *testing.T) {
+		var (
+			clock = glock.NewMockClock()
+			queue = makeQueue(clock)
+			ctx   = context.Background()
+		)
+
+		_, err := queue.Enqueue(ctx, []byte("a"))
+		require.Nil(t, err)
+		stale, _, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+
+		clock.Advance(time.Minute)
+		current, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		// The lapsed lease does not make the job visible to a third consumer
+		assert.Equal(t, ErrJobNotFound, queue.Nack(ctx, stale.Receipt, 0, errors.New("oops")))
+		_, ok, err = queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		require.Nil(t, queue.Nack(ctx, current.Receipt, 0, errors.New("oops")))
+		job, ok, err := queue.Dequeue(ctx, time.Minute)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, 3, job.Attempts)
+		require.Len(t, job.Errors, 1)
+		assert.Equal(t, 2, job.Errors[0].Attempt)
+	})
+}
+
+func enqueueAll(t *testing.T, queue Queue, payloads ...string) {
+	for _, payload := range payloads {
+		_, err := queue.Enqueue(context.Background(), []byte(payload))
+		require.Nil(t, err)
+	}
+}
+
+// dequeueAll dequeues up to n jobs and returns their payloads.
+func dequeueAll(t *testing.T, queue Queue, n int) []string {
+	var payloads []string
+	for i := 0; i < n; i++ {
+		job, ok, err := queue.Dequeue(context.Background(), time.Minute)
+		require.Nil(t, err)
+		if !ok {
+			break
+		}
+
+		payloads = append(payloads, string(job.Payload))
+	}
+
+	return payloads
+}
@@ -0,0 +1,71 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/nacelle/v2"
+	"github.com/go-nacelle/workerbase"
+)
+
+// Scheduler is a worker spec that moves due jobs from a delayed store onto a
+// queue, where they are handled by a Consumer. One job is moved per tick. While
+// due jobs remain the worker ticks again immediately, so a job is moved no later
+// than one worker interval after its run-at time.
+//
+// A job is removed from the store only after it has been enqueued. If the
+// process exits between the two, the job is enqueued again on the next run.
+type Scheduler struct {
+	Logger nacelle.Logger `service:"logger" optional:"true"`
+	store  DelayedStore
+	queue  Queue
+	clock  glock.Clock
+}
+
+var _ workerbase.ResultTicker = &Scheduler{}
+
+// NewScheduler creates a worker spec that moves jobs from the given delayed
+// store onto the given queue once they are due.
+func NewScheduler(store DelayedStore, queue Queue) *Scheduler {
+	return newScheduler(store, queue, glock.NewRealClock())
+}
+
+func newScheduler(store DelayedStore, queue Queue, clock glock.Clock) *Scheduler {
+	return &Scheduler{
+		store: store,
+		queue: queue,
+		clock: clock,
+	}
+}
+
+func (s *Scheduler) Init(ctx context.Context) error {
+	if s.Logger == nil {
+		s.Logger = nacelle.NewNilLogger()
+	}
+
+	return nil
+}
+
+func (s *Scheduler) Tick(ctx context.Context) error {
+	_, err := s.TickWithResult(ctx)
+	return err
+}
+
+func (s *Scheduler) TickWithResult(ctx context.Context) (workerbase.TickResult, error) {
+	job, ok, err := s.store.Due(ctx, s.clock.Now())
+	if err != nil || !ok {
+		return workerbase.TickResult{}, err
+	}
+
+	id, err := s.queue.Enqueue(ctx, job.Payload)
+	if err != nil {
+		return workerbase.TickResult{}, err
+	}
+
+	if err := s.store.Remove(ctx, job.ID); err != nil {
+		return workerbase.TickResult{}, err
+	}
+
+	s.Logger.Debug("Enqueued delayed job %s as job %s", job.ID, id)
+	return workerbase.TickResult{MoreWorkPending: true}, nil
+}
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/workerbase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	var (
+		clock     = glock.NewMockClock()
+		store     = NewMemoryDelayedStore()
+		queue     = newMemoryQueue(clock)
+		scheduler = newScheduler(store, queue, clock)
+		ctx       = context.Background()
+	)
+	require.Nil(t, scheduler.Init(ctx))
+
+	_, err := store.Schedule(ctx, []byte("later"), clock.Now().Add(time.Hour))
+	require.Nil(t, err)
+	_, err = store.Schedule(ctx, []byte("now"), clock.Now())
+	require.Nil(t, err)
+
+	result, err := scheduler.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, workerbase.TickResult{MoreWorkPending: true}, result)
+
+	result, err = scheduler.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, workerbase.TickResult{}, result)
+
+	job, ok, err := queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("now"), job.Payload)
+	require.Nil(t, queue.Ack(ctx, job.Receipt))
+
+	_, ok, err = queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	assert.False(t, ok)
+
+	// The later job is moved once the clock reaches its run-at time
+	clock.Advance(time.Hour)
+	result, err = scheduler.TickWithResult(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, workerbase.TickResult{MoreWorkPending: true}, result)
+
+	job, ok, err = queue.Dequeue(ctx, time.Minute)
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("later"), job.Payload)
+}
+
+func TestSchedulerEnqueueError(t *testing.T) {
+	var (
+		clock     = glock.NewMockClock()
+		store     = NewMemoryDelayedStore()
+		queue     = &errorQueue{err: errors.New("oops")}
+		scheduler = newScheduler(store, queue, clock)
+		ctx       = context.Background()
+	)
+	require.Nil(t, scheduler.Init(ctx))
+
+	id, err := store.Schedule(ctx, []byte("now"), clock.Now())
+	require.Nil(t, err)
+
+	_, err = scheduler.TickWithResult(ctx)
+	assert.EqualError(t, err, "oops")
+
+	// The job remains in the store to be retried
+	job, ok, err := store.Due(ctx, clock.Now())
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, id, job.ID)
+}
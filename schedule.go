@@ -0,0 +1,322 @@
+package workerbase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule determines the time at which a worker should next invoke
+// its spec's tick method.
+type Schedule interface {
+	// Next returns the first activation time strictly after the given
+	// time. A zero time is returned if the schedule will never fire.
+	Next(t time.Time) time.Time
+}
+
+// cronSchedule is a Schedule described by a cron expression. Each field
+// is stored as a bitset where bit n is set if the value n is allowed.
+type cronSchedule struct {
+	second   uint64
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domAny   bool
+	dowAny   bool
+	location *time.Location
+}
+
+type cronBounds struct {
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	secondBounds = cronBounds{min: 0, max: 59}
+	minuteBounds = cronBounds{min: 0, max: 59}
+	hourBounds   = cronBounds{min: 0, max: 23}
+	domBounds    = cronBounds{min: 1, max: 31}
+	monthBounds  = cronBounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = cronBounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// maxScheduleSearchYears bounds the search for the next activation time
+// of a schedule that can never be satisfied (e.g. February 30th).
+const maxScheduleSearchYears = 5
+
+// parseCronSchedule parses a standard five-field (minute, hour, day of
+// month, month, day of week) or six-field (with a leading seconds field)
+// cron expression, or one of the predefined descriptors such as @hourly.
+// Activation times are computed in the given location.
+func parseCronSchedule(expression string, location *time.Location) (Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		descriptor, ok := cronDescriptors[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized schedule descriptor %q", fields[0])
+		}
+
+		fields = strings.Fields(descriptor)
+	}
+
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("schedule %q must have five or six fields", expression)
+	}
+
+	schedule := &cronSchedule{location: location}
+	targets := []struct {
+		bits   *uint64
+		bounds cronBounds
+	}{
+		{&schedule.second, secondBounds},
+		{&schedule.minute, minuteBounds},
+		{&schedule.hour, hourBounds},
+		{&schedule.dom, domBounds},
+		{&schedule.month, monthBounds},
+		{&schedule.dow, dowBounds},
+	}
+
+	for i, target := range targets {
+		bits, err := parseCronField(fields[i], target.bounds)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", expression, err)
+		}
+
+		*target.bits = bits
+	}
+
+	// Sunday may be written as either 0 or 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = (schedule.dow | 1) &^ (1 << 7)
+	}
+
+	schedule.domAny = isWildcard(fields[3])
+	schedule.dowAny = isWildcard(fields[5])
+	return schedule, nil
+}
+
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseCronField parses a comma-separated list of values, ranges, and
+// steps into a bitset.
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		partBits, err := parseCronRange(part, bounds)
+		if err != nil {
+			return 0, err
+		}
+
+		bits |= partBits
+	}
+
+	return bits, nil
+}
+
+// parseCronRange parses a single term of the form `*`, `a`, `a-b`,
+// `*/n`, `a/n`, or `a-b/n` into a bitset.
+func parseCronRange(term string, bounds cronBounds) (uint64, error) {
+	rangeAndStep := strings.Split(term, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("malformed term %q", term)
+	}
+
+	var start, end int
+	if isWildcard(rangeAndStep[0]) {
+		start, end = bounds.min, bounds.max
+	} else {
+		lowAndHigh := strings.Split(rangeAndStep[0], "-")
+		if len(lowAndHigh) > 2 {
+			return 0, fmt.Errorf("malformed term %q", term)
+		}
+
+		var err error
+		if start, err = parseCronValue(lowAndHigh[0], bounds); err != nil {
+			return 0, err
+		}
+
+		end = start
+		if len(lowAndHigh) == 2 {
+			if end, err = parseCronValue(lowAndHigh[1], bounds); err != nil {
+				return 0, err
+			}
+		} else if len(rangeAndStep) == 2 {
+			end = bounds.max
+		}
+	}
+
+	step := 1
+	if len(rangeAndStep) == 2 {
+		var err error
+		if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step in term %q", term)
+		}
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("range start exceeds range end in term %q", term)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, bounds cronBounds) (int, error) {
+	if n, ok := bounds.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	if n < bounds.min || n > bounds.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", n, bounds.min, bounds.max)
+	}
+
+	return n, nil
+}
+
+// Next returns the first activation time strictly after the given time.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	originalLocation := t.Location()
+	t = t.In(s.location)
+
+	// Start at the earliest possible activation time (the next whole second).
+	// The search walks forward field-by-field, from most to least significant,
+	// and restarts whenever a less significant field wraps around.
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	yearLimit := t.Year() + maxScheduleSearchYears
+	truncated := false
+
+search:
+	for t.Year() <= yearLimit {
+		for s.month&(1<<uint(t.Month())) == 0 {
+			if !truncated {
+				truncated = true
+				t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.location)
+			}
+
+			// Advance from midnight explicitly, as midnight does not exist on days
+			// when daylight saving time begins at midnight in some locations
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			if t.Month() == time.January {
+				continue search
+			}
+		}
+
+		for !s.dayMatches(t) {
+			if !truncated {
+				truncated = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+			}
+
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			if t.Day() == 1 {
+				continue search
+			}
+		}
+
+		for s.hour&(1<<uint(t.Hour())) == 0 {
+			if !truncated {
+				truncated = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.location)
+			}
+
+			day := t.Day()
+			t = t.Add(time.Hour)
+			if t.Day() != day {
+				// Hour zero may be skipped by a daylight saving transition, so the
+				// day is compared rather than the hour
+				continue search
+			}
+		}
+
+		for s.minute&(1<<uint(t.Minute())) == 0 {
+			if !truncated {
+				truncated = true
+				t = t.Truncate(time.Minute)
+			}
+
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				continue search
+			}
+		}
+
+		for s.second&(1<<uint(t.Second())) == 0 {
+			if !truncated {
+				truncated = true
+				t = t.Truncate(time.Second)
+			}
+
+			t = t.Add(time.Second)
+			if t.Second() == 0 {
+				continue search
+			}
+		}
+
+		return t.In(originalLocation)
+	}
+
+	return time.Time{}
+}
+
+// dayMatches returns true if the given time satisfies the day-of-month and
+// day-of-week fields. Following cron convention, if both fields are
+// restricted then a match on either field is sufficient.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// makeSchedule parses the given cron expression in the given named time
+// zone. A nil schedule is returned if the expression is empty.
+func makeSchedule(expression, timezone string) (Schedule, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule timezone %q: %w", timezone, err)
+	}
+
+	return parseCronSchedule(expression, location)
+}
@@ -0,0 +1,171 @@
+package workerbase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Monday
+	start := time.Date(2021, time.January, 4, 10, 20, 30, 0, time.UTC)
+
+	testCases := []struct {
+		expression string
+		expected   []time.Time
+	}{
+		{
+			expression: "* * * * *",
+			expected: []time.Time{
+				time.Date(2021, time.January, 4, 10, 21, 0, 0, time.UTC),
+				time.Date(2021, time.January, 4, 10, 22, 0, 0, time.UTC),
+			},
+		},
+		{
+			expression: "*/15 * * * * *",
+			expected: []time.Time{
+				time.Date(2021, time.January, 4, 10, 20, 45, 0, time.UTC),
+				time.Date(2021, time.January, 4, 10, 21, 0, 0, time.UTC),
+			},
+		},
+		{
+			expression: "15 2 * * mon-fri",
+			expected: []time.Time{
+				time.Date(2021, time.January, 5, 2, 15, 0, 0, time.UTC),
+				time.Date(2021, time.January, 6, 2, 15, 0, 0, time.UTC),
+				time.Date(2021, time.January, 7, 2, 15, 0, 0, time.UTC),
+				time.Date(2021, time.January, 8, 2, 15, 0, 0, time.UTC),
+				time.Date(2021, time.January, 11, 2, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			expression: "@hourly",
+			expected: []time.Time{
+				time.Date(2021, time.January, 4, 11, 0, 0, 0, time.UTC),
+				time.Date(2021, time.January, 4, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expression: "@monthly",
+			expected: []time.Time{
+				time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expression: "0 0 29 2 *",
+			expected: []time.Time{
+				time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// Restricted day-of-month and day-of-week fields match either
+			expression: "0 0 10 * 7",
+			expected: []time.Time{
+				time.Date(2021, time.January, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.January, 17, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.January, 24, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.February, 7, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.February, 10, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expression, func(t *testing.T) {
+			schedule, err := parseCronSchedule(testCase.expression, time.UTC)
+			require.Nil(t, err)
+
+			var actual []time.Time
+			for next := start; len(actual) < len(testCase.expected); {
+				next = schedule.Next(next)
+				actual = append(actual, next)
+			}
+
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestCronScheduleLocation(t *testing.T) {
+	location := time.FixedZone("UTC-5", -5*60*60)
+	schedule, err := parseCronSchedule("0 9 * * *", location)
+	require.Nil(t, err)
+
+	next := schedule.Next(time.Date(2021, time.January, 4, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2021, time.January, 4, 14, 0, 0, 0, time.UTC), next)
+	assert.Equal(t, time.UTC, next.Location())
+}
+
+func TestCronScheduleMidnightDaylightSavingTransition(t *testing.T) {
+	// Daylight saving time begins at midnight in these locations, so hour zero
+	// does not occur on the day of the transition
+	testCases := []struct {
+		timezone string
+		start    time.Time
+		expected time.Time
+	}{
+		{
+			// Saturday before the transition on Sunday, September 11
+			timezone: "America/Santiago",
+			start:    time.Date(2022, time.September, 10, 6, 0, 0, 0, time.UTC),
+			expected: time.Date(2022, time.September, 17, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			// Saturday before the transition on Sunday, November 4
+			timezone: "America/Sao_Paulo",
+			start:    time.Date(2018, time.November, 3, 6, 0, 0, 0, time.UTC),
+			expected: time.Date(2018, time.November, 10, 5, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.timezone, func(t *testing.T) {
+			location, err := time.LoadLocation(testCase.timezone)
+			if err != nil {
+				t.Skipf("timezone data unavailable: %s", err)
+			}
+
+			// The test times are given as wall-clock times in the location
+			inLocation := func(t time.Time) time.Time {
+				return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, location)
+			}
+
+			// Saturdays at 05:00
+			schedule, err := parseCronSchedule("0 5 * * 6", location)
+			require.Nil(t, err)
+
+			next := schedule.Next(inLocation(testCase.start))
+			assert.Equal(t, inLocation(testCase.expected).UTC(), next.UTC())
+		})
+	}
+}
+
+func TestCronScheduleNeverFires(t *testing.T) {
+	schedule, err := parseCronSchedule("0 0 30 2 *", time.UTC)
+	require.Nil(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"@fortnightly",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * foo *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"1-2-3 * * * *",
+	} {
+		_, err := parseCronSchedule(expression, time.UTC)
+		assert.NotNil(t, err, "expression %q", expression)
+	}
+}
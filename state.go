@@ -0,0 +1,85 @@
+package workerbase
+
+// WorkerState describes the lifecycle state of a worker.
+type WorkerState int
+
+const (
+	// StateIdle indicates that the worker has not started running.
+	StateIdle WorkerState = iota
+
+	// StateRunning indicates that the worker is ticking.
+	StateRunning
+
+	// StatePaused indicates that the worker has been paused and will not
+	// begin new ticks until it is resumed.
+	StatePaused
+
+	// StateStopped indicates that the worker has been stopped.
+	StateStopped
+)
+
+func (s WorkerState) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateRunning:
+		return "running"
+	case StatePaused:
+		return "paused"
+	case StateStopped:
+		return "stopped"
+	}
+
+	return "unknown"
+}
+
+// State returns the current state of the worker.
+func (w *Worker) State() WorkerState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch {
+	case w.halted():
+		return StateStopped
+	case w.paused:
+		return StatePaused
+	case w.running:
+		return StateRunning
+	}
+
+	return StateIdle
+}
+
+// Pause instructs the worker to stop beginning new ticks. Ticks that are
+// in-flight are allowed to finish. A paused worker continues to report
+// itself as healthy; use State to distinguish a paused worker. Pausing a
+// worker that is already paused is a no-op.
+func (w *Worker) Pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.paused {
+		return
+	}
+
+	w.paused = true
+	w.resumeSignal = make(chan struct{})
+	close(w.pauseSignal)
+}
+
+// Resume instructs a paused worker to begin ticking again. The tick interval
+// or schedule is re-anchored to the time the worker resumes: without a schedule
+// the worker ticks immediately, otherwise it waits for the next activation time.
+// Resuming a worker that is not paused is a no-op.
+func (w *Worker) Resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.paused {
+		return
+	}
+
+	w.paused = false
+	w.pauseSignal = make(chan struct{})
+	close(w.resumeSignal)
+}
@@ -0,0 +1,176 @@
+package workerbase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/nacelle/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseAndResume(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		spec    = &resultWorkerSpec{tickRecorder: tickRecorder{clock: clock}}
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "60",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, StateIdle, worker.State())
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.getTimes()) == 1 })
+	assert.Equal(t, StateRunning, worker.State())
+
+	worker.Pause()
+	assert.Equal(t, StatePaused, worker.State())
+	assert.True(t, worker.healthStatus.Healthy())
+
+	// No ticks are scheduled while paused
+	clock.Advance(time.Minute * 10)
+	consistently(t, func() bool { return len(spec.getTimes()) == 1 })
+
+	// Resuming ticks immediately and re-anchors the interval
+	worker.Resume()
+	assert.Equal(t, StateRunning, worker.State())
+	eventually(t, func() bool { return len(spec.getTimes()) == 2 })
+	clock.BlockingAdvance(time.Minute)
+	eventually(t, func() bool { return len(spec.getTimes()) == 3 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	assert.Equal(t, StateStopped, worker.State())
+
+	expected := []time.Time{
+		start,
+		start.Add(time.Minute * 10),
+		start.Add(time.Minute * 11),
+	}
+	assert.Equal(t, expected, spec.getTimes())
+}
+
+func TestPauseDuringTick(t *testing.T) {
+	var (
+		spec        = NewMockWorkerSpecFinalizer()
+		clock       = glock.NewMockClock()
+		worker      = makeWorker(spec, clock)
+		tickChan    = make(chan struct{}, 1)
+		releaseChan = make(chan struct{})
+		ctxErrChan  = make(chan error, 1)
+		errChan     = make(chan error)
+	)
+
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		<-releaseChan
+		ctxErrChan <- ctx.Err()
+		return nil
+	})
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, receiveStruct(tickChan))
+	worker.Pause()
+
+	// In-flight tick finishes with a live context
+	close(releaseChan)
+	assert.Nil(t, <-ctxErrChan)
+
+	clock.Advance(time.Second * 5)
+	assertStructChanDoesNotReceive(t, tickChan)
+
+	worker.Resume()
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestPauseBeforeRun(t *testing.T) {
+	var (
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock)
+		tickChan = make(chan struct{}, 1)
+		errChan  = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	worker.Pause()
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	assertStructChanDoesNotReceive(t, tickChan)
+	worker.Resume()
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestStopWhilePaused(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.TickFunc.History()) == 1 })
+	worker.Pause()
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	assert.Equal(t, StateStopped, worker.State())
+}
@@ -0,0 +1,373 @@
+package workerbase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/go-nacelle/config/v3"
+	"github.com/go-nacelle/nacelle/v2"
+)
+
+// Strategies controlling which children a Supervisor restarts when a child fails.
+const (
+	// StrategyOneForOne restarts only the failed child.
+	StrategyOneForOne = "one-for-one"
+
+	// StrategyOneForAll stops and restarts every child.
+	StrategyOneForAll = "one-for-all"
+
+	// StrategyRestForOne stops and restarts the failed child and every child
+	// supervised after it.
+	StrategyRestForOne = "rest-for-one"
+)
+
+// Supervisor runs a set of workers as a single process and restarts workers
+// that fail according to a restart strategy. If children are restarted more
+// frequently than the configured restart intensity allows, the supervisor
+// stops every child and fails with a RestartIntensityError.
+type Supervisor struct {
+	Config        *nacelle.Config           `service:"config"`
+	Services      *nacelle.ServiceContainer `service:"services"`
+	Health        *nacelle.Health           `service:"health"`
+	Logger        nacelle.Logger            `service:"logger" optional:"true"`
+	Checkpointer  Checkpointer              `service:"checkpointer" optional:"true"`
+	tagModifiers  []config.TagModifier
+	clock         glock.Clock
+	strategy      string
+	maxRestarts   int
+	restartPeriod time.Duration
+	reinit        bool
+	restarts      []time.Time
+	exits         chan childExit
+	halt          chan struct{}
+	done          chan struct{}
+	once          *sync.Once
+
+	// The following fields are protected by mu
+	mu       sync.Mutex
+	running  bool
+	children []*Worker
+}
+
+type childExit struct {
+	index  int
+	worker *Worker
+	err    error
+}
+
+// SupervisorConfig is the configuration loaded by a Supervisor on initialization.
+type SupervisorConfig struct {
+	Strategy         string `env:"worker_supervisor_strategy" default:"one-for-one"`
+	MaxRestarts      int    `env:"worker_supervisor_max_restarts" default:"3"`
+	RawRestartPeriod string `env:"worker_supervisor_restart_period" default:"5s"`
+	Reinit           bool   `env:"worker_supervisor_reinit" default:"true"`
+
+	RestartPeriod time.Duration
+}
+
+func (c *SupervisorConfig) PostLoad() error {
+	switch c.Strategy {
+	case StrategyOneForOne, StrategyOneForAll, StrategyRestForOne:
+	default:
+		return fmt.Errorf("unknown supervisor strategy %q", c.Strategy)
+	}
+
+	if c.MaxRestarts < 0 {
+		return fmt.Errorf("supervisor max restarts must not be negative")
+	}
+
+	var err error
+	if c.RestartPeriod, err = parseDuration(c.RawRestartPeriod); err != nil {
+		return fmt.Errorf("invalid supervisor restart period: %w", err)
+	}
+
+	return nil
+}
+
+// SupervisorConfigFunc is a function used to configure an instance of a Supervisor.
+type SupervisorConfigFunc func(*Supervisor)
+
+// WithSupervisorTagModifiers applies the given tag modifiers when loading the
+// supervisor's configuration. These modifiers do not apply to the configuration
+// of the supervised workers.
+func WithSupervisorTagModifiers(modifiers ...config.TagModifier) SupervisorConfigFunc {
+	return func(s *Supervisor) { s.tagModifiers = append(s.tagModifiers, modifiers...) }
+}
+
+// NewSupervisor creates a supervisor of the given workers. Workers are started in
+// the given order, which determines the children restarted by the rest-for-one
+// strategy. The supervisor supplies each worker with its own services, so the
+// workers should not also be registered as processes.
+func NewSupervisor(children []*Worker, configs ...SupervisorConfigFunc) *Supervisor {
+	return newSupervisor(glock.NewRealClock(), children, configs...)
+}
+
+func newSupervisor(clock glock.Clock, children []*Worker, configs ...SupervisorConfigFunc) *Supervisor {
+	s := &Supervisor{
+		clock:    clock,
+		children: children,
+		exits:    make(chan childExit, len(children)*2),
+		halt:     make(chan struct{}),
+		done:     make(chan struct{}),
+		once:     &sync.Once{},
+	}
+
+	for _, f := range configs {
+		f(s)
+	}
+
+	return s
+}
+
+func (s *Supervisor) Init(ctx context.Context) error {
+	if s.Logger == nil {
+		s.Logger = nacelle.NewNilLogger()
+	}
+
+	supervisorConfig := &SupervisorConfig{}
+	if err := s.Config.Load(supervisorConfig, s.tagModifiers...); err != nil {
+		return err
+	}
+
+	s.strategy = supervisorConfig.Strategy
+	s.maxRestarts = supervisorConfig.MaxRestarts
+	s.restartPeriod = supervisorConfig.RestartPeriod
+	s.reinit = supervisorConfig.Reinit
+
+	for i, child := range s.children {
+		child.Config = s.Config
+		child.Services = s.Services
+		child.Health = s.Health
+		child.Logger = s.Logger
+		child.Checkpointer = s.Checkpointer
+		child.skipFinalize = !s.reinit
+
+		if err := child.Init(ctx); err != nil {
+			return fmt.Errorf("failed to initialize worker %s: %w", childName(child, i), err)
+		}
+	}
+
+	return nil
+}
+
+// Run runs every child until the supervisor is stopped or every child exits
+// without error. Children that fail are restarted according to the supervisor's
+// strategy.
+func (s *Supervisor) Run(ctx context.Context) (err error) {
+	defer s.Stop(ctx)
+	defer close(s.done)
+
+	s.mu.Lock()
+	if s.halted() {
+		// Stopped before running, so no children are started and the specs
+		// initialized by Init are finalized here
+		s.mu.Unlock()
+		return s.finalizeChildren(ctx)
+	}
+	s.running = true
+	children := append([]*Worker(nil), s.children...)
+	s.mu.Unlock()
+
+	for i, child := range children {
+		s.startChild(ctx, i, child)
+	}
+
+	for live := len(children); live > 0; live-- {
+		exit := <-s.exits
+
+		s.mu.Lock()
+		current := s.children[exit.index] == exit.worker
+		s.mu.Unlock()
+
+		if !current || exit.err == nil || s.halted() || err != nil {
+			// Exits of children stopped by the supervisor itself, or after the
+			// supervisor has begun stopping, require no further action
+			continue
+		}
+
+		started, restartErr := s.handleChildFailure(ctx, exit)
+		if restartErr != nil {
+			err = restartErr
+			s.signalHalt()
+			_ = s.stopChildren(ctx)
+		}
+
+		live += started
+	}
+
+	if !s.reinit {
+		// Specs reused across restarts are finalized only once every child
+		// has exited for the last time
+		if finalizeErr := s.finalizeChildren(ctx); err == nil {
+			err = finalizeErr
+		}
+	}
+
+	return err
+}
+
+// finalizeChildren finalizes the specs of the current worker of each child. The
+// first error is returned.
+func (s *Supervisor) finalizeChildren(ctx context.Context) (err error) {
+	for _, child := range s.Children() {
+		if finalizeErr := child.finalize(ctx); err == nil {
+			err = finalizeErr
+		}
+	}
+
+	return err
+}
+
+// handleChildFailure restarts children in response to the failure of the given
+// child. The number of children started is returned. An error is returned if the
+// restart intensity is exceeded or a child could not be reinitialized.
+func (s *Supervisor) handleChildFailure(ctx context.Context, exit childExit) (int, error) {
+	name := childName(exit.worker, exit.index)
+
+	now := s.clock.Now()
+	restarts := s.restarts[:0]
+	for _, t := range s.restarts {
+		if now.Sub(t) < s.restartPeriod {
+			restarts = append(restarts, t)
+		}
+	}
+	s.restarts = append(restarts, now)
+
+	if len(s.restarts) > s.maxRestarts {
+		s.Logger.Error("Worker %s failed, restart intensity exceeded (%s)", name, exit.err)
+		return 0, &RestartIntensityError{MaxRestarts: s.maxRestarts, Period: s.restartPeriod, Err: exit.err}
+	}
+
+	first, last := exit.index, exit.index
+	switch s.strategy {
+	case StrategyOneForAll:
+		first, last = 0, len(s.children)-1
+	case StrategyRestForOne:
+		last = len(s.children) - 1
+	}
+
+	s.Logger.Error("Worker %s failed, restarting (%s)", name, exit.err)
+
+	// Stop the siblings restarted alongside the failed child. Stopped children
+	// are replaced below, so their exits are ignored by the run loop.
+	for i := first; i <= last; i++ {
+		if i != exit.index {
+			s.mu.Lock()
+			child := s.children[i]
+			s.mu.Unlock()
+
+			_ = child.Stop(ctx)
+		}
+	}
+
+	started := 0
+	for i := first; i <= last; i++ {
+		s.mu.Lock()
+		child := s.children[i].restart(s.reinit)
+		s.mu.Unlock()
+
+		if err := child.Init(ctx); err != nil {
+			return started, fmt.Errorf("failed to reinitialize worker %s: %w", childName(child, i), err)
+		}
+
+		s.mu.Lock()
+		if s.halted() {
+			s.mu.Unlock()
+
+			if s.reinit {
+				// The reinitialized child is never run, so its specs are finalized
+				// here. Reused specs are finalized when the supervisor exits.
+				return started, child.finalize(ctx)
+			}
+
+			return started, nil
+		}
+		s.children[i] = child
+		s.mu.Unlock()
+
+		s.startChild(ctx, i, child)
+		started++
+	}
+
+	return started, nil
+}
+
+func (s *Supervisor) startChild(ctx context.Context, index int, child *Worker) {
+	go func() {
+		s.exits <- childExit{index: index, worker: child, err: child.Run(ctx)}
+	}()
+}
+
+// Children returns the current worker for each supervised child. A child's
+// worker is replaced each time it is restarted.
+func (s *Supervisor) Children() []*Worker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Worker(nil), s.children...)
+}
+
+// Stop instructs every child to stop and blocks until they do. If the given
+// context is done before Run exits, its error is returned. Calling Stop on a
+// supervisor that has not started running is a no-op.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.signalHalt()
+
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+
+	if !running {
+		return nil
+	}
+
+	if err := s.stopChildren(ctx); err != nil {
+		return err
+	}
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stopChildren stops the current worker of each child.
+func (s *Supervisor) stopChildren(ctx context.Context) error {
+	children := s.Children()
+
+	var errs []error
+	for _, child := range children {
+		if err := child.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return newMultiError(errs)
+}
+
+// halted returns true if the supervisor has been instructed to stop.
+func (s *Supervisor) halted() bool {
+	select {
+	case <-s.halt:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Supervisor) signalHalt() {
+	s.once.Do(func() { close(s.halt) })
+}
+
+func childName(child *Worker, index int) string {
+	if child.name != "" {
+		return fmt.Sprintf("%q", child.name)
+	}
+
+	return fmt.Sprintf("#%d", index)
+}
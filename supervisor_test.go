@@ -0,0 +1,290 @@
+package workerbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/go-nacelle/nacelle/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupervisorStrategies(t *testing.T) {
+	testCases := []struct {
+		strategy  string
+		initCalls []int
+	}{
+		{strategy: StrategyOneForOne, initCalls: []int{1, 2, 1}},
+		{strategy: StrategyOneForAll, initCalls: []int{2, 2, 2}},
+		{strategy: StrategyRestForOne, initCalls: []int{1, 2, 2}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.strategy, func(t *testing.T) {
+			var (
+				specs   = []*MockWorkerSpecFinalizer{NewMockWorkerSpecFinalizer(), NewMockWorkerSpecFinalizer(), NewMockWorkerSpecFinalizer()}
+				clock   = glock.NewMockClock()
+				errChan = make(chan error)
+			)
+
+			specs[1].TickFunc.PushHook(func(ctx context.Context) error {
+				return fmt.Errorf("oops")
+			})
+
+			supervisor := makeSupervisor(clock, specs)
+			supervisor.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+				"worker_tick_interval":       "5",
+				"worker_supervisor_strategy": testCase.strategy,
+			}))
+
+			ctx := context.Background()
+			err := supervisor.Init(ctx)
+			require.Nil(t, err)
+
+			go func() {
+				errChan <- supervisor.Run(ctx)
+			}()
+
+			eventually(t, func() bool { return len(specs[1].TickFunc.History()) == 2 })
+			eventually(t, func() bool {
+				for i, spec := range specs {
+					if len(spec.InitFunc.History()) != testCase.initCalls[i] {
+						return false
+					}
+				}
+
+				return true
+			})
+
+			for _, child := range supervisor.Children() {
+				eventually(t, func() bool { return child.State() == StateRunning })
+			}
+
+			err = supervisor.Stop(ctx)
+			require.Nil(t, err)
+			value := readErrorValue(t, errChan)
+			assert.Nil(t, value)
+
+			for i, spec := range specs {
+				// Each worker finalizes its spec when it stops
+				mockassert.CalledN(t, spec.FinalizeFunc, testCase.initCalls[i])
+			}
+		})
+	}
+}
+
+func TestSupervisorRestartIntensity(t *testing.T) {
+	var (
+		specs   = []*MockWorkerSpecFinalizer{NewMockWorkerSpecFinalizer(), NewMockWorkerSpecFinalizer()}
+		clock   = glock.NewMockClock()
+		errChan = make(chan error)
+	)
+
+	specs[1].TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	supervisor := makeSupervisor(clock, specs)
+	supervisor.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":             "5",
+		"worker_supervisor_max_restarts":   "2",
+		"worker_supervisor_restart_period": "10s",
+	}))
+
+	ctx := context.Background()
+	err := supervisor.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- supervisor.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	require.NotNil(t, value)
+
+	var intensityErr *RestartIntensityError
+	require.True(t, errors.As(value, &intensityErr))
+	assert.Equal(t, 2, intensityErr.MaxRestarts)
+	assert.Equal(t, time.Second*10, intensityErr.Period)
+	assert.EqualError(t, value, "more than 2 restarts within 10s: oops")
+	mockassert.CalledN(t, specs[1].TickFunc, 3)
+	mockassert.CalledOnce(t, specs[0].FinalizeFunc)
+}
+
+func TestSupervisorRestartIntensityWindow(t *testing.T) {
+	var (
+		specs   = []*MockWorkerSpecFinalizer{NewMockWorkerSpecFinalizer()}
+		clock   = glock.NewMockClock()
+		errChan = make(chan error)
+	)
+
+	specs[0].TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		clock.Advance(time.Second * 6)
+		return fmt.Errorf("oops")
+	})
+
+	supervisor := makeSupervisor(clock, specs)
+	supervisor.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":             "5",
+		"worker_supervisor_max_restarts":   "1",
+		"worker_supervisor_restart_period": "5s",
+	}))
+
+	ctx := context.Background()
+	err := supervisor.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- supervisor.Run(ctx)
+	}()
+
+	// Restarts are spread further apart than the restart period
+	eventually(t, func() bool { return len(specs[0].TickFunc.History()) > 5 })
+
+	err = supervisor.Stop(ctx)
+	require.Nil(t, err)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestSupervisorWithoutReinit(t *testing.T) {
+	var (
+		specs   = []*MockWorkerSpecFinalizer{NewMockWorkerSpecFinalizer()}
+		clock   = glock.NewMockClock()
+		errChan = make(chan error)
+	)
+
+	specs[0].TickFunc.PushHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	supervisor := makeSupervisor(clock, specs)
+	supervisor.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":     "5",
+		"worker_supervisor_reinit": "false",
+	}))
+
+	ctx := context.Background()
+	err := supervisor.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- supervisor.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(specs[0].TickFunc.History()) == 2 })
+	mockassert.CalledOnce(t, specs[0].InitFunc)
+
+	// The reused spec is not finalized before the restarted child ticks it
+	mockassert.NotCalled(t, specs[0].FinalizeFunc)
+
+	err = supervisor.Stop(ctx)
+	require.Nil(t, err)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.CalledOnce(t, specs[0].FinalizeFunc)
+}
+
+func TestSupervisorStopBeforeRun(t *testing.T) {
+	var (
+		specs   = []*MockWorkerSpecFinalizer{NewMockWorkerSpecFinalizer(), NewMockWorkerSpecFinalizer()}
+		clock   = glock.NewMockClock()
+		errChan = make(chan error)
+	)
+
+	supervisor := makeSupervisor(clock, specs)
+	supervisor.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "5",
+	}))
+
+	ctx := context.Background()
+	err := supervisor.Init(ctx)
+	require.Nil(t, err)
+	require.Nil(t, supervisor.Stop(ctx))
+
+	go func() {
+		errChan <- supervisor.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	for _, spec := range specs {
+		mockassert.NotCalled(t, spec.TickFunc)
+		mockassert.CalledOnce(t, spec.FinalizeFunc)
+	}
+}
+
+func TestSupervisorStopDuringRestart(t *testing.T) {
+	var (
+		specs   = []*MockWorkerSpecFinalizer{NewMockWorkerSpecFinalizer()}
+		clock   = glock.NewMockClock()
+		errChan = make(chan error)
+	)
+
+	supervisor := makeSupervisor(clock, specs)
+	supervisor.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "5",
+	}))
+
+	specs[0].TickFunc.PushHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+	specs[0].InitFunc.PushHook(func(ctx context.Context) error {
+		return nil
+	})
+	specs[0].InitFunc.PushHook(func(ctx context.Context) error {
+		// The supervisor is stopped while the failed child is reinitialized
+		supervisor.signalHalt()
+		return nil
+	})
+
+	ctx := context.Background()
+	err := supervisor.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- supervisor.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	// The reinitialized spec is finalized even though it never runs
+	mockassert.CalledOnce(t, specs[0].TickFunc)
+	mockassert.CalledN(t, specs[0].InitFunc, 2)
+	mockassert.CalledN(t, specs[0].FinalizeFunc, 2)
+}
+
+func TestSupervisorInitError(t *testing.T) {
+	var (
+		specs = []*MockWorkerSpecFinalizer{NewMockWorkerSpecFinalizer()}
+		clock = glock.NewMockClock()
+	)
+
+	supervisor := makeSupervisor(clock, specs)
+	supervisor.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_supervisor_strategy": "all-for-none",
+	}))
+
+	err := supervisor.Init(context.Background())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown supervisor strategy")
+}
+
+func makeSupervisor(clock glock.Clock, specs []*MockWorkerSpecFinalizer, configs ...SupervisorConfigFunc) *Supervisor {
+	children := make([]*Worker, 0, len(specs))
+	for i, spec := range specs {
+		children = append(children, newWorker(spec, nil, clock, WithName(fmt.Sprintf("child-%d", i))))
+	}
+
+	supervisor := newSupervisor(clock, children, configs...)
+	supervisor.Services = nacelle.NewServiceContainer()
+	supervisor.Health = nacelle.NewHealth()
+	return supervisor
+}
@@ -0,0 +1,125 @@
+package workerbase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/derision-test/glock"
+)
+
+// Attributes attached to the span of each tick.
+const (
+	AttributeWorkerName    = "worker.name"
+	AttributeTickNumber    = "worker.tick_number"
+	AttributeScheduledTime = "worker.scheduled_time"
+	AttributeStartTime     = "worker.start_time"
+	AttributeStartDelay    = "worker.start_delay"
+)
+
+// TickSpanName is the name of the span wrapping each tick.
+const TickSpanName = "worker.tick"
+
+type (
+	// Tracer begins a span around each tick of a worker. This interface can be
+	// implemented by a thin adapter over a tracing library such as OpenTelemetry.
+	Tracer interface {
+		// Start begins a span with the given name and attributes. The returned
+		// context is passed to the tick and should carry the new span.
+		Start(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span)
+	}
+
+	// Span is a traced unit of work begun by a Tracer.
+	Span interface {
+		// End completes the span. A non-nil error marks the span as failed.
+		End(err error)
+	}
+)
+
+func tracingMiddleware(clock glock.Clock, tracer Tracer) TickMiddleware {
+	return func(next TickFunc) TickFunc {
+		return func(ctx context.Context) error {
+			var (
+				started   = clock.Now()
+				scheduled = ScheduledTimeFromContext(ctx)
+			)
+
+			attributes := map[string]interface{}{
+				AttributeWorkerName:    WorkerNameFromContext(ctx),
+				AttributeTickNumber:    TickNumberFromContext(ctx),
+				AttributeScheduledTime: scheduled,
+				AttributeStartTime:     started,
+				AttributeStartDelay:    started.Sub(scheduled),
+			}
+
+			ctx, span := tracer.Start(ctx, TickSpanName, attributes)
+			err := next(ctx)
+			span.End(err)
+			return err
+		}
+	}
+}
+
+// RecordingTracer is a Tracer that retains its spans in memory. It is intended
+// for use in tests.
+type RecordingTracer struct {
+	mutex sync.Mutex
+	spans []*recordingSpan
+}
+
+// RecordedSpan is a snapshot of a span begun by a RecordingTracer.
+type RecordedSpan struct {
+	Name       string
+	Attributes map[string]interface{}
+	Ended      bool
+	Err        error
+}
+
+type recordingSpan struct {
+	tracer *RecordingTracer
+	span   RecordedSpan
+}
+
+var _ Tracer = &RecordingTracer{}
+
+// NewRecordingTracer creates a new, empty RecordingTracer.
+func NewRecordingTracer() *RecordingTracer {
+	return &RecordingTracer{}
+}
+
+// Start records a new span.
+func (t *RecordingTracer) Start(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span) {
+	copied := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		copied[key] = value
+	}
+
+	span := &recordingSpan{tracer: t, span: RecordedSpan{Name: name, Attributes: copied}}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.spans = append(t.spans, span)
+
+	return ctx, span
+}
+
+// Spans returns a snapshot of the spans recorded so far, in the order in which
+// they were started.
+func (t *RecordingTracer) Spans() []RecordedSpan {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	spans := make([]RecordedSpan, 0, len(t.spans))
+	for _, span := range t.spans {
+		spans = append(spans, span.span)
+	}
+
+	return spans
+}
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+
+	s.span.Ended = true
+	s.span.Err = err
+}
@@ -0,0 +1,77 @@
+package workerbase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracer(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		tracer  = NewRecordingTracer()
+		errChan = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		clock.Advance(time.Second * 2)
+		return nil
+	})
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	worker := makeWorker(spec, clock, WithName("mailer"), WithTracer(tracer))
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.TickFunc.History()) == 1 })
+	clock.BlockingAdvance(time.Second * 6)
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "oops")
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, RecordedSpan{
+		Name: TickSpanName,
+		Attributes: map[string]interface{}{
+			AttributeWorkerName:    "mailer",
+			AttributeTickNumber:    int64(1),
+			AttributeScheduledTime: start,
+			AttributeStartTime:     start,
+			AttributeStartDelay:    time.Duration(0),
+		},
+		Ended: true,
+	}, spans[0])
+
+	// The second tick is scheduled after the first but the clock overshoots
+	assert.Equal(t, RecordedSpan{
+		Name: TickSpanName,
+		Attributes: map[string]interface{}{
+			AttributeWorkerName:    "mailer",
+			AttributeTickNumber:    int64(2),
+			AttributeScheduledTime: start.Add(time.Second * 7),
+			AttributeStartTime:     start.Add(time.Second * 8),
+			AttributeStartDelay:    time.Second,
+		},
+		Ended: true,
+		Err:   fmt.Errorf("oops"),
+	}, spans[1])
+}
@@ -2,6 +2,7 @@ package workerbase
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -14,19 +15,67 @@ import (
 
 type (
 	Worker struct {
-		Config       *nacelle.Config           `service:"config"`
-		Services     *nacelle.ServiceContainer `service:"services"`
-		Health       *nacelle.Health           `service:"health"`
-		tagModifiers []nacelle.TagModifier
-		spec         WorkerSpec
-		clock        glock.Clock
-		halt         chan struct{}
-		done         chan struct{}
-		once         *sync.Once
-		tickInterval time.Duration
-		strictClock  bool
-		healthToken  healthToken
-		healthStatus *process.HealthComponentStatus
+		Config                *nacelle.Config           `service:"config"`
+		Services              *nacelle.ServiceContainer `service:"services"`
+		Health                *nacelle.Health           `service:"health"`
+		Logger                nacelle.Logger            `service:"logger" optional:"true"`
+		Checkpointer          Checkpointer              `service:"checkpointer" optional:"true"`
+		configs               []ConfigFunc
+		name                  string
+		tagModifiers          []nacelle.TagModifier
+		defaultSchedule       string
+		spec                  WorkerSpec
+		factory               func() WorkerSpec
+		specs                 []WorkerSpec
+		clock                 glock.Clock
+		random                func() float64
+		halt                  chan struct{}
+		done                  chan struct{}
+		once                  *sync.Once
+		tickInterval          time.Duration
+		tickJitter            time.Duration
+		tickJitterFraction    float64
+		initialJitter         time.Duration
+		initialDelay          time.Duration
+		tickOnStart           bool
+		strictClock           bool
+		fixedRate             bool
+		missedTickPolicy      string
+		schedule              Schedule
+		retry                 *backoff
+		concurrency           int
+		isolateErrors         bool
+		tickTimeout           time.Duration
+		tickTimeoutPolicy     string
+		panicPolicy           string
+		drainPeriod           time.Duration
+		trigger               chan struct{}
+		triggerSources        []<-chan struct{}
+		middleware            []TickMiddleware
+		metrics               MetricsCollector
+		tracer                Tracer
+		triggerResetsInterval bool
+		failureThreshold      int
+		stalenessWindow       time.Duration
+		successSignal         chan struct{}
+		healthToken           healthToken
+		healthStatus          *process.HealthComponentStatus
+
+		// skipFinalize is set by a supervisor that reuses the worker's specs
+		// after a restart, and finalizes them itself on final shutdown
+		skipFinalize bool
+
+		// The following fields are protected by mu
+		mu                  sync.Mutex
+		running             bool
+		cancelTicks         context.CancelFunc
+		paused              bool
+		pauseSignal         chan struct{}
+		resumeSignal        chan struct{}
+		tickCount           int64
+		consecutiveFailures int
+		lastSuccess         time.Time
+		stale               bool
 	}
 
 	WorkerSpec interface {
@@ -34,6 +83,30 @@ type (
 		Tick(ctx context.Context) error
 	}
 
+	// NextIntervaler is an optional interface for a worker spec that decides the
+	// delay before its next tick. This method is called after each successful tick
+	// and its result replaces the configured interval or schedule. A non-positive
+	// duration causes the spec to be ticked again immediately.
+	NextIntervaler interface {
+		NextInterval() time.Duration
+	}
+
+	// ResultTicker is an optional interface for a worker spec that reports the
+	// outcome of a tick. If implemented, this method is called in place of Tick.
+	ResultTicker interface {
+		TickWithResult(ctx context.Context) (TickResult, error)
+	}
+
+	// TickResult describes the outcome of a successful tick.
+	TickResult struct {
+		// MoreWorkPending causes the spec to be ticked again immediately.
+		MoreWorkPending bool
+
+		// NextInterval, if positive, replaces the configured interval or schedule
+		// for the delay before the next tick.
+		NextInterval time.Duration
+	}
+
 	workerSpecFinalizer interface {
 		process.Finalizer
 		WorkerSpec
@@ -41,29 +114,55 @@ type (
 )
 
 func NewWorker(spec WorkerSpec, configs ...ConfigFunc) *Worker {
-	return newWorker(spec, glock.NewRealClock(), configs...)
+	return newWorker(spec, nil, glock.NewRealClock(), configs...)
+}
+
+// NewWorkerFromFactory creates a worker that constructs a distinct spec instance
+// for each concurrent tick loop (see WORKER_CONCURRENCY). A worker created with
+// NewWorker instead shares a single spec instance between all tick loops.
+func NewWorkerFromFactory(factory func() WorkerSpec, configs ...ConfigFunc) *Worker {
+	return newWorker(nil, factory, glock.NewRealClock(), configs...)
 }
 
-func newWorker(spec WorkerSpec, clock glock.Clock, configs ...ConfigFunc) *Worker {
+func newWorker(spec WorkerSpec, factory func() WorkerSpec, clock glock.Clock, configs ...ConfigFunc) *Worker {
 	options := getOptions(configs)
 
 	return &Worker{
-		tagModifiers: options.tagModifiers,
-		spec:         spec,
-		clock:        clock,
-		halt:         make(chan struct{}),
-		done:         make(chan struct{}),
-		once:         &sync.Once{},
-		healthToken:  healthToken(uuid.New().String()),
+		configs:         configs,
+		name:            options.name,
+		tagModifiers:    options.tagModifiers,
+		defaultSchedule: options.schedule,
+		spec:            spec,
+		factory:         factory,
+		clock:           clock,
+		halt:            make(chan struct{}),
+		done:            make(chan struct{}),
+		once:            &sync.Once{},
+		pauseSignal:     make(chan struct{}),
+		trigger:         make(chan struct{}, 1),
+		successSignal:   make(chan struct{}, 1),
+		triggerSources:  options.triggerSources,
+		middleware:      options.middleware,
+		metrics:         options.metrics,
+		tracer:          options.tracer,
+		random:          options.random,
+		healthToken:     healthToken(uuid.New().String()),
 	}
 }
 
 func (w *Worker) Init(ctx context.Context) error {
-	healthStatus, err := w.Health.Register(w.healthToken)
-	if err != nil {
-		return err
+	if w.Logger == nil {
+		w.Logger = nacelle.NewNilLogger()
+	}
+
+	// A restarted worker retains the health status of the worker it replaces
+	if w.healthStatus == nil {
+		healthStatus, err := w.Health.Register(w.healthToken)
+		if err != nil {
+			return err
+		}
+		w.healthStatus = healthStatus
 	}
-	w.healthStatus = healthStatus
 
 	workerConfig := &Config{}
 	if err := w.Config.Load(workerConfig, w.tagModifiers...); err != nil {
@@ -71,20 +170,73 @@ func (w *Worker) Init(ctx context.Context) error {
 	}
 
 	w.strictClock = workerConfig.StrictClock
+	w.fixedRate = workerConfig.FixedRate
+	w.missedTickPolicy = workerConfig.MissedTickPolicy
 	w.tickInterval = workerConfig.WorkerTickInterval
+	w.tickJitter = workerConfig.TickJitter
+	w.tickJitterFraction = workerConfig.TickJitterFraction
+	w.initialJitter = workerConfig.InitialJitter
+	w.initialDelay = workerConfig.InitialDelay
+	w.tickOnStart = workerConfig.TickOnStart
+	w.concurrency = workerConfig.Concurrency
+	w.isolateErrors = workerConfig.ConcurrencyErrorPolicy == ErrorPolicyIsolate
+	w.tickTimeout = workerConfig.TickTimeout
+	w.tickTimeoutPolicy = workerConfig.TickTimeoutPolicy
+	w.panicPolicy = workerConfig.PanicPolicy
+	w.drainPeriod = workerConfig.DrainPeriod
+	w.triggerResetsInterval = workerConfig.TriggerResetsInterval
+	w.failureThreshold = workerConfig.UnhealthyFailureThreshold
+	w.stalenessWindow = workerConfig.UnhealthyStaleness
+
+	expression := workerConfig.Schedule
+	if expression == "" {
+		expression = w.defaultSchedule
+	}
 
-	if err := service.Inject(ctx, w.Services, w.spec); err != nil {
+	schedule, err := makeSchedule(expression, workerConfig.ScheduleTimezone)
+	if err != nil {
 		return err
 	}
+	w.schedule = schedule
+
+	if workerConfig.RetryEnabled {
+		w.retry = newBackoff(workerConfig, w.random)
+	}
+
+	if w.specs != nil {
+		// A restarted worker may reuse the initialized specs of the worker it replaces
+		return nil
+	}
+
+	w.specs = []WorkerSpec{w.spec}
+	if w.factory != nil {
+		w.specs = make([]WorkerSpec, 0, w.concurrency)
+		for i := 0; i < w.concurrency; i++ {
+			w.specs = append(w.specs, w.factory())
+		}
+	}
+
+	for i, spec := range w.specs {
+		if err := service.Inject(ctx, w.Services, spec); err != nil {
+			return err
+		}
+
+		if err := spec.Init(ctx); err != nil {
+			return err
+		}
+
+		if err := w.restoreCheckpoint(ctx, i); err != nil {
+			return err
+		}
+	}
 
-	return w.spec.Init(ctx)
+	return nil
 }
 
 func (w *Worker) Run(ctx context.Context) (err error) {
-	if finalizer, ok := w.spec.(nacelle.Finalizer); ok {
+	if !w.skipFinalize {
 		defer func() {
-			finalizeErr := finalizer.Finalize(ctx)
-			if err == nil {
+			if finalizeErr := w.finalize(ctx); err == nil {
 				err = finalizeErr
 			}
 		}()
@@ -92,38 +244,610 @@ func (w *Worker) Run(ctx context.Context) (err error) {
 
 	defer w.Stop(ctx)
 
-	w.healthStatus.Update(true)
+	// Tick contexts carry the values of the run context, but are cancelled only
+	// once the worker is halted
+	ctx, cancel := context.WithCancel(detachContext(ctx))
+	defer cancel()
+
+	// The halt check and the running flag are updated together so that a
+	// concurrent call to Stop either prevents the worker from starting or
+	// waits for it to exit
+	w.mu.Lock()
+	if w.halted() {
+		w.mu.Unlock()
+		return nil
+	}
+	w.running = true
+	w.cancelTicks = cancel
+	w.lastSuccess = w.clock.Now()
+	w.mu.Unlock()
 	defer close(w.done)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	w.healthStatus.Update(true)
+
+	loopsDone := make(chan struct{})
+	defer close(loopsDone)
 
 	go func() {
 		<-w.halt
+
+		// Give in-flight ticks a chance to finish before cancelling their context
+		if w.drainPeriod > 0 {
+			select {
+			case <-loopsDone:
+			case <-w.clock.After(w.drainPeriod):
+			}
+		}
+
 		cancel()
 	}()
 
+	for _, source := range w.triggerSources {
+		go w.forwardTriggers(source)
+	}
+
+	if w.stalenessWindow > 0 {
+		go w.monitorStaleness()
+	}
+
+	errs := make(chan error, w.concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+
+			if err := w.runLoop(ctx, index); err != nil {
+				errs <- err
+
+				if !w.isolateErrors {
+					w.signalHalt()
+				}
+			}
+		}(i % len(w.specs))
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var loopErrs []error
+	for err := range errs {
+		loopErrs = append(loopErrs, err)
+	}
+
+	if w.isolateErrors && len(loopErrs) < w.concurrency {
+		// At least one loop exited cleanly; the errors of the failed
+		// loops were already logged as they occurred.
+		return nil
+	}
+
+	if err := newMultiError(loopErrs); err != nil {
+		// A worker that exits with an error reports itself as unhealthy until it
+		// is run again
+		w.healthStatus.Update(false)
+		return err
+	}
+
+	return nil
+}
+
+// runLoop invokes the tick method of the spec with the given index until the
+// worker is halted or a tick fails with an error that is not retried.
+func (w *Worker) runLoop(ctx context.Context, index int) error {
+	var (
+		scheduled = w.initialTickTime()
+		slot      time.Time
+		displaced time.Time
+		retrying  bool
+		retry     = w.retry.clone()
+	)
+
+	// wait blocks until the next tick should begin. If a wait for a regular tick
+	// is cut short by a trigger, the displaced scheduled time is remembered so that
+	// the regular cadence can be restored after the triggered tick. A pending retry
+	// cut short by a trigger is not restored; the triggered tick takes its place.
+	// If the scheduled time is re-anchored on resume, a pending retry is abandoned.
+	wait := func() bool {
+		previous := scheduled
+
+		switch w.waitForTick(&scheduled) {
+		case wakeHalted:
+			return false
+		case wakeTriggered:
+			if !retrying {
+				displaced = scheduled
+			}
+			scheduled = w.clock.Now()
+		case wakeScheduled:
+			if !scheduled.Equal(previous) {
+				retrying = false
+			}
+		}
+
+		return true
+	}
+
+	if w.schedule != nil || scheduled.After(w.clock.Now()) || w.State() == StatePaused {
+		if !wait() {
+			return nil
+		}
+	}
+
 	for {
+		// Fixed-rate slots are measured from the scheduled time of the most
+		// recent tick that was not a retry
+		if !retrying {
+			slot = scheduled
+		}
+
 		started := w.clock.Now()
-		if err = w.spec.Tick(ctx); err != nil {
-			return
+		result, err := w.tick(ctx, index, scheduled)
+		w.recordTickOutcome(err)
+
+		resumeAt := displaced
+		displaced = time.Time{}
+
+		if err != nil {
+			action, delay := w.handleTickError(err, retry)
+
+			switch action {
+			case actionFatal:
+				return w.loopFailed(err)
+
+			case actionRetry:
+				scheduled = w.clock.Now().Add(delay)
+				retrying = true
+				if !wait() {
+					return nil
+				}
+
+				continue
+			}
+		} else if retry != nil {
+			retry.reset()
+		}
+
+		retrying = false
+		if !resumeAt.IsZero() && !w.triggerResetsInterval {
+			scheduled = resumeAt
+		} else {
+			scheduled = w.nextTickTime(started, slot, result)
+		}
+
+		if !wait() {
+			return nil
+		}
+	}
+}
+
+// tickErrorAction describes how a tick loop proceeds after a failed tick.
+type tickErrorAction int
+
+const (
+	// actionFatal exits the tick loop with the tick error.
+	actionFatal tickErrorAction = iota
+
+	// actionRetry ticks again after a delay.
+	actionRetry
+
+	// actionSkip waits for the next regularly scheduled tick.
+	actionSkip
+)
+
+// handleTickError logs the given tick error and decides how the tick loop should
+// proceed. If the tick should be retried, the delay before the retry is returned.
+func (w *Worker) handleTickError(err error, retry *backoff) (tickErrorAction, time.Duration) {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		w.Logger.ErrorWithFields(nacelle.LogFields{"stack": string(panicErr.Stack)}, "Worker tick panicked (%v)", panicErr.Value)
+
+		if w.panicPolicy == PanicPolicyFatal {
+			return actionFatal, 0
+		}
+		if retry == nil {
+			return actionSkip, 0
+		}
+	}
+
+	if isTickTimeout(err) {
+		switch w.tickTimeoutPolicy {
+		case TimeoutPolicyFatal:
+			return actionFatal, 0
+
+		case TimeoutPolicySkip:
+			w.Logger.Warning("Worker tick timed out, skipping (%s)", err)
+			return actionSkip, 0
+
+		case TimeoutPolicyRetry:
+			if retry == nil {
+				w.Logger.Warning("Worker tick timed out, retrying (%s)", err)
+				return actionRetry, 0
+			}
+		}
+	}
+
+	if retry == nil {
+		return actionFatal, 0
+	}
+
+	delay, ok := retry.next()
+	if !ok {
+		return actionFatal, 0
+	}
+
+	w.Logger.Warning("Worker tick failed, retrying in %s (%s)", delay, err)
+	return actionRetry, delay
+}
+
+// loopFailed logs the error of a failed tick loop if tick loops are isolated
+// from one another, then returns the error unchanged.
+func (w *Worker) loopFailed(err error) error {
+	if w.isolateErrors {
+		w.Logger.Error("Worker tick loop exited (%s)", err)
+	}
+
+	return err
+}
+
+// tick invokes the tick method of the spec with the given index through the
+// configured middleware chain, bounded by the configured tick timeout. If the
+// tick's deadline is exceeded, a TickTimeoutError is returned. A panic anywhere
+// within the chain is returned as a PanicError. The tick context carries the worker name, tick number, and the
+// given scheduled time.
+func (w *Worker) tick(ctx context.Context, index int, scheduled time.Time) (TickResult, error) {
+	spec := w.specs[index]
+
+	ctx = contextWithTickInfo(ctx, tickInfo{
+		workerName:    w.name,
+		tickNumber:    w.nextTickNumber(),
+		scheduledTime: scheduled,
+	})
+
+	var result TickResult
+	tick := func(ctx context.Context) (err error) {
+		if result, err = invokeTick(ctx, spec); err != nil {
+			return err
+		}
+
+		return w.saveCheckpoint(ctx, index)
+	}
+
+	var middleware []TickMiddleware
+	if w.tracer != nil {
+		middleware = append(middleware, tracingMiddleware(w.clock, w.tracer))
+	}
+	if w.metrics != nil {
+		middleware = append(middleware, metricsMiddleware(w.clock, w.name, w.metrics))
+	}
+	middleware = append(middleware, RecoveryMiddleware())
+	middleware = append(middleware, w.middleware...)
+	if w.tickTimeout > 0 {
+		middleware = append(middleware, timeoutMiddleware(w.clock, w.tickTimeout))
+	}
+
+	if err := chainMiddleware(tick, middleware)(ctx); err != nil {
+		return TickResult{}, err
+	}
+
+	return result, nil
+}
+
+// invokeTick invokes the spec's tick method. If the spec implements ResultTicker
+// or NextIntervaler, the returned result reflects the spec's preferred delay before
+// the next tick.
+func invokeTick(ctx context.Context, spec WorkerSpec) (TickResult, error) {
+	if ticker, ok := spec.(ResultTicker); ok {
+		return ticker.TickWithResult(ctx)
+	}
+
+	if err := spec.Tick(ctx); err != nil {
+		return TickResult{}, err
+	}
+
+	if intervaler, ok := spec.(NextIntervaler); ok {
+		interval := intervaler.NextInterval()
+		return TickResult{MoreWorkPending: interval <= 0, NextInterval: interval}, nil
+	}
+
+	return TickResult{}, nil
+}
+
+// nextTickNumber returns the sequence number of a new tick.
+func (w *Worker) nextTickNumber() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.tickCount++
+	return w.tickCount
+}
+
+// nextTickTime returns the time at which the next tick should begin given the
+// result of the tick that began at the given time. A zero time is returned if
+// the spec should never be ticked again.
+func (w *Worker) nextTickTime(started, slot time.Time, result TickResult) time.Time {
+	now := w.clock.Now()
+
+	if result.MoreWorkPending {
+		return now
+	}
+
+	if result.NextInterval > 0 {
+		return now.Add(result.NextInterval)
+	}
+
+	if w.schedule != nil {
+		return w.schedule.Next(now)
+	}
+
+	if w.fixedRate && w.tickInterval > 0 {
+		return w.nextSlot(slot, now)
+	}
+
+	if w.strictClock {
+		return started.Add(w.jitteredInterval())
+	}
+
+	return now.Add(w.jitteredInterval())
+}
+
+// nextSlot returns the fixed-rate slot following the given slot. Slots that
+// began before the current time while the previous tick was running are handled
+// according to the configured missed tick policy.
+func (w *Worker) nextSlot(slot, now time.Time) time.Time {
+	var missed int64
+	if elapsed := now.Sub(slot); elapsed > 0 {
+		missed = int64((elapsed - 1) / w.tickInterval)
+	}
+
+	if missed > 0 {
+		switch w.missedTickPolicy {
+		case MissedTickPolicySkip:
+			w.recordSkippedSlots(missed)
+			return slot.Add(time.Duration(missed+1) * w.tickInterval)
+
+		case MissedTickPolicyRunOnce:
+			w.recordSkippedSlots(missed - 1)
+			return slot.Add(time.Duration(missed) * w.tickInterval)
+		}
+	}
+
+	return slot.Add(w.tickInterval)
+}
+
+// recordSkippedSlots reports fixed-rate slots that were skipped without a tick.
+func (w *Worker) recordSkippedSlots(n int64) {
+	if n <= 0 {
+		return
+	}
+
+	w.Logger.Warning("Worker tick overran its interval, skipping %d missed tick(s)", n)
+
+	if recorder, ok := w.metrics.(SkippedSlotsRecorder); ok {
+		recorder.SlotsSkipped(w.name, n)
+	}
+}
+
+// jitteredInterval returns the configured tick interval offset by a random
+// duration within the configured tick jitter in either direction.
+func (w *Worker) jitteredInterval() time.Duration {
+	jitter := w.tickJitter
+	if w.tickJitterFraction > 0 {
+		jitter = time.Duration(w.tickJitterFraction * float64(w.tickInterval))
+	}
+	if jitter <= 0 {
+		return w.tickInterval
+	}
+
+	interval := w.tickInterval + time.Duration((2*w.random()-1)*float64(jitter))
+	if interval < 0 {
+		return 0
+	}
+
+	return interval
+}
+
+// initialTickTime returns the time at which the first tick should begin when
+// the worker starts, after the configured initial delay and jitter.
+func (w *Worker) initialTickTime() time.Time {
+	start := w.clock.Now().Add(w.initialDelay)
+	if w.schedule != nil {
+		start = w.schedule.Next(start)
+	} else if !w.tickOnStart {
+		start = start.Add(w.jitteredInterval())
+	}
+
+	if w.initialJitter > 0 && !start.IsZero() {
+		start = start.Add(time.Duration(w.random() * float64(w.initialJitter)))
+	}
+
+	return start
+}
+
+// anchoredTickTime returns the time at which the first tick should begin when
+// the worker starts or resumes.
+func (w *Worker) anchoredTickTime() time.Time {
+	now := w.clock.Now()
+	if w.schedule != nil {
+		return w.schedule.Next(now)
+	}
+
+	return now
+}
+
+// wakeReason describes why a tick loop stopped waiting for its next tick.
+type wakeReason int
+
+const (
+	wakeHalted wakeReason = iota
+	wakeScheduled
+	wakeTriggered
+	wakePaused
+)
+
+// waitForTick blocks until the given scheduled time or until the worker is
+// triggered. If the worker is paused while waiting, this method blocks until
+// the worker is resumed, then updates the scheduled time to be re-anchored to
+// the current time.
+func (w *Worker) waitForTick(scheduled *time.Time) wakeReason {
+	for {
+		w.mu.Lock()
+		paused, pauseSignal, resumeSignal := w.paused, w.pauseSignal, w.resumeSignal
+		w.mu.Unlock()
+
+		if paused {
+			select {
+			case <-w.halt:
+				return wakeHalted
+			case <-resumeSignal:
+			}
+
+			*scheduled = w.anchoredTickTime()
+			continue
+		}
+
+		reason := w.sleepUntil(*scheduled, pauseSignal)
+		if reason == wakePaused || (reason == wakeScheduled && w.State() == StatePaused) {
+			continue
 		}
 
-		interval := w.tickInterval
-		if w.strictClock {
-			interval -= w.clock.Now().Sub(started)
+		return reason
+	}
+}
+
+// sleepUntil blocks until the given time, until the worker is triggered, or
+// until the given pause signal is closed. A zero time blocks until the worker
+// is halted, triggered, or paused.
+func (w *Worker) sleepUntil(t time.Time, pauseSignal <-chan struct{}) wakeReason {
+	var timeout <-chan time.Time
+	if !t.IsZero() {
+		duration := t.Sub(w.clock.Now())
+		if duration <= 0 {
+			if w.halted() {
+				return wakeHalted
+			}
+
+			return wakeScheduled
+		}
+
+		timeout = w.clock.After(duration)
+	}
+
+	select {
+	case <-w.halt:
+		return wakeHalted
+	case <-pauseSignal:
+		return wakePaused
+	case <-w.trigger:
+		return wakeTriggered
+	case <-timeout:
+		return wakeScheduled
+	}
+}
+
+// restart creates a new worker for the same spec and options as the receiver,
+// which can no longer be run once stopped. The new worker shares the services
+// and health status of the receiver, and must be initialized before it is run.
+// If reinit is false, initializing the new worker reloads its configuration but
+// reuses the receiver's specs without initializing them again.
+func (w *Worker) restart(reinit bool) *Worker {
+	restarted := newWorker(w.spec, w.factory, w.clock, w.configs...)
+	restarted.Config = w.Config
+	restarted.Services = w.Services
+	restarted.Health = w.Health
+	restarted.Logger = w.Logger
+	restarted.Checkpointer = w.Checkpointer
+	restarted.random = w.random
+	restarted.healthToken = w.healthToken
+	restarted.healthStatus = w.healthStatus
+	restarted.skipFinalize = w.skipFinalize
+
+	if !reinit {
+		restarted.specs = w.specs
+	}
+
+	return restarted
+}
+
+// finalize calls the Finalize method of each spec that implements it, in the
+// reverse order of the specs. The first error is returned.
+func (w *Worker) finalize(ctx context.Context) (err error) {
+	for i := len(w.specs) - 1; i >= 0; i-- {
+		if finalizer, ok := w.specs[i].(nacelle.Finalizer); ok {
+			if finalizeErr := finalizer.Finalize(ctx); err == nil {
+				err = finalizeErr
+			}
 		}
+	}
+
+	return err
+}
+
+// Stop instructs the worker to stop ticking and blocks until the current
+// ticks finish. In-flight ticks have their context cancelled after the
+// configured drain period, or immediately once the given context is done.
+// If the given context is done before Run exits, its error is returned.
+// Calling Stop on a worker that has not started running returns immediately,
+// and a later call to Run returns without ticking.
+func (w *Worker) Stop(ctx context.Context) error {
+	w.signalHalt()
+
+	w.mu.Lock()
+	running, cancelTicks := w.running, w.cancelTicks
+	w.mu.Unlock()
+
+	if !running {
+		return nil
+	}
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		cancelTicks()
+		return ctx.Err()
+	}
+}
 
+// Trigger wakes the worker to begin a tick immediately, without waiting for the
+// configured interval or schedule. Triggers that occur while all tick loops are
+// busy are coalesced into a single follow-up tick.
+func (w *Worker) Trigger() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// forwardTriggers triggers the worker each time a value is received from the
+// given source, until the source is closed or the worker is halted.
+func (w *Worker) forwardTriggers(source <-chan struct{}) {
+	for {
 		select {
 		case <-w.halt:
 			return
-		case <-w.clock.After(interval):
+		case _, ok := <-source:
+			if !ok {
+				return
+			}
+
+			w.Trigger()
 		}
 	}
 }
 
-func (w *Worker) Stop(ctx context.Context) error {
+// halted returns true if the worker has been instructed to stop ticking.
+func (w *Worker) halted() bool {
+	select {
+	case <-w.halt:
+		return true
+	default:
+		return false
+	}
+}
+
+// signalHalt instructs all tick loops to exit without waiting for them to do so.
+func (w *Worker) signalHalt() {
 	w.once.Do(func() { close(w.halt) })
-	<-w.done
-	return nil
 }
@@ -1,10 +1,13 @@
 package workerbase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -202,120 +205,1582 @@ func TestStrict(t *testing.T) {
 	assert.Equal(t, expected, times[:3])
 }
 
+func TestSchedule(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	times := []time.Time{}
+	mutex := sync.Mutex{}
+
+	lockedLen := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(times)
+	}
+
+	start := time.Date(2021, time.January, 4, 10, 20, 30, 0, time.UTC)
+	clock.SetCurrent(start)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		mutex.Lock()
+		times = append(times, clock.Now())
+		mutex.Unlock()
+
+		clock.Advance(time.Second * 30)
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_schedule":          "0 * * * *",
+		"worker_schedule_timezone": "UTC",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	// Does not tick on start
+	consistently(t, func() bool { return lockedLen() == 0 })
+
+	clock.BlockingAdvance(time.Minute*39 + time.Second*30)
+	eventually(t, func() bool { return lockedLen() == 1 })
+	clock.BlockingAdvance(time.Minute*59 + time.Second*30)
+	eventually(t, func() bool { return lockedLen() == 2 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	expected := []time.Time{
+		time.Date(2021, time.January, 4, 11, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 4, 12, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, expected, times)
+}
+
+func TestScheduleOption(t *testing.T) {
+	worker := makeWorker(NewMockWorkerSpecFinalizer(), glock.NewMockClock(), WithSchedule("@daily"))
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+	require.NotNil(t, worker.schedule)
+
+	worker = makeWorker(NewMockWorkerSpecFinalizer(), glock.NewMockClock(), WithSchedule("@daily"))
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_schedule":          "0 0 1 1 *",
+		"worker_schedule_timezone": "UTC",
+	}))
+
+	err = worker.Init(ctx)
+	require.Nil(t, err)
+
+	// Configuration takes precedence over the option
+	start := time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), worker.schedule.Next(start))
+}
+
+func TestInitScheduleError(t *testing.T) {
+	worker := makeWorker(NewMockWorkerSpecFinalizer(), glock.NewMockClock())
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_schedule": "* * *",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.NotNil(t, err)
+
+	worker = makeWorker(NewMockWorkerSpecFinalizer(), glock.NewMockClock())
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_schedule":          "@hourly",
+		"worker_schedule_timezone": "Nowhere/Special",
+	}))
+
+	err = worker.Init(ctx)
+	assert.NotNil(t, err)
+}
+
+func TestNextInterval(t *testing.T) {
+	var (
+		clock     = glock.NewMockClock()
+		intervals = []time.Duration{time.Second * 10, 0, time.Second * 20}
+		spec      = &nextIntervalWorkerSpec{tickRecorder: tickRecorder{clock: clock}, intervals: intervals}
+		worker    = makeWorker(spec, clock)
+		errChan   = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	clock.BlockingAdvance(time.Second * 10)
+	clock.BlockingAdvance(time.Second * 20)
+	clock.BlockingAdvance(time.Second * 5)
+	eventually(t, func() bool { return len(spec.getTimes()) == 5 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	expected := []time.Time{
+		start,
+		start.Add(time.Second * 10),
+		start.Add(time.Second * 10),
+		start.Add(time.Second * 30),
+		start.Add(time.Second * 35),
+	}
+	assert.Equal(t, expected, spec.getTimes())
+}
+
+func TestTickWithResult(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		spec    = &resultWorkerSpec{tickRecorder: tickRecorder{clock: clock}}
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	spec.results = []TickResult{
+		{MoreWorkPending: true},
+		{MoreWorkPending: true},
+		{NextInterval: time.Second * 30},
+		{},
+	}
+
+	start := time.Now()
+	clock.SetCurrent(start)
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	clock.BlockingAdvance(time.Second * 30)
+	clock.BlockingAdvance(time.Second * 5)
+	eventually(t, func() bool { return len(spec.getTimes()) == 5 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	expected := []time.Time{
+		start,
+		start,
+		start,
+		start.Add(time.Second * 30),
+		start.Add(time.Second * 35),
+	}
+	assert.Equal(t, expected, spec.getTimes())
+}
+
+func TestConcurrency(t *testing.T) {
+	var (
+		spec      = NewMockWorkerSpecFinalizer()
+		clock     = glock.NewMockClock()
+		worker    = makeWorker(spec, clock)
+		startChan = make(chan struct{}, 3)
+		errChan   = make(chan error)
+		inFlight  int32
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		startChan <- struct{}{}
+		<-ctx.Done()
+		return nil
+	})
+	spec.FinalizeFunc.SetDefaultHook(func(ctx context.Context) error {
+		// All in-flight ticks have returned before finalization
+		assert.Equal(t, int32(0), atomic.LoadInt32(&inFlight))
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_concurrency": "3",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	for i := 0; i < 3; i++ {
+		eventually(t, receiveStruct(startChan))
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&inFlight))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.CalledOnce(t, spec.InitFunc)
+	mockassert.CalledOnce(t, spec.FinalizeFunc)
+}
+
+func TestConcurrencyFactory(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		specs   = []*MockWorkerSpecFinalizer{}
+		errChan = make(chan error)
+	)
+
+	factory := func() WorkerSpec {
+		spec := NewMockWorkerSpecFinalizer()
+		specs = append(specs, spec)
+		return spec
+	}
+
+	worker := newWorker(nil, factory, clock)
+	worker.Services = nacelle.NewServiceContainer()
+	worker.Health = nacelle.NewHealth()
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "5",
+		"worker_concurrency":   "3",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+	require.Len(t, specs, 3)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	for _, spec := range specs {
+		spec := spec
+		eventually(t, func() bool { return len(spec.TickFunc.History()) == 1 })
+	}
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	for _, spec := range specs {
+		mockassert.CalledOnce(t, spec.InitFunc)
+		mockassert.CalledOnce(t, spec.FinalizeFunc)
+	}
+}
+
+func TestConcurrencyErrorCancel(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+		calls   int32
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) == 3 {
+			return fmt.Errorf("oops")
+		}
+
+		<-ctx.Done()
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_concurrency": "3",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "oops")
+	mockassert.CalledOnce(t, spec.FinalizeFunc)
+}
+
+func TestConcurrencyErrorIsolate(t *testing.T) {
+	var (
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock)
+		tickChan = make(chan struct{}, 3)
+		errChan  = make(chan error)
+		calls    int32
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return fmt.Errorf("oops")
+		}
+
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":            "5",
+		"worker_concurrency":              "3",
+		"worker_concurrency_error_policy": "isolate",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	// Remaining loops continue to tick
+	eventually(t, receiveStruct(tickChan))
+	eventually(t, receiveStruct(tickChan))
+	clock.BlockingAdvance(time.Second * 5)
+	eventually(t, receiveStruct(tickChan))
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestConcurrencyErrorIsolateAllFailed(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_concurrency":              "2",
+		"worker_concurrency_error_policy": "isolate",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "oops; oops")
+}
+
+func TestTickContextValues(t *testing.T) {
+	type keyType struct{}
+
+	var (
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock, WithName("billing"))
+		tickChan = make(chan context.Context, 1)
+		errChan  = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		clock.Advance(time.Second)
+		tickChan <- ctx
+		return nil
+	})
+	worker.Config = testConfig
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), keyType{}, "value"))
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	tickCtx := <-tickChan
+	assert.Equal(t, "value", tickCtx.Value(keyType{}))
+	assert.Equal(t, "billing", WorkerNameFromContext(tickCtx))
+	assert.Equal(t, int64(1), TickNumberFromContext(tickCtx))
+	assert.Equal(t, start, ScheduledTimeFromContext(tickCtx))
+
+	clock.BlockingAdvance(time.Second * 5)
+	tickCtx = <-tickChan
+	assert.Equal(t, int64(2), TickNumberFromContext(tickCtx))
+	assert.Equal(t, start.Add(time.Second*6), ScheduledTimeFromContext(tickCtx))
+
+	// Cancellation of the run context does not cancel the tick context
+	cancel()
+	assert.Nil(t, tickCtx.Err())
+
+	worker.Stop(context.Background())
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	assert.Equal(t, context.Canceled, tickCtx.Err())
+}
+
+func TestTrigger(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		spec    = &resultWorkerSpec{tickRecorder: tickRecorder{clock: clock}}
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "60",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.getTimes()) == 1 })
+	clock.BlockingAdvance(time.Second * 30)
+	worker.Trigger()
+	eventually(t, func() bool { return len(spec.getTimes()) == 2 })
+
+	// Regular cadence is unaffected by the triggered tick
+	clock.BlockingAdvance(time.Second * 30)
+	eventually(t, func() bool { return len(spec.getTimes()) == 3 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	expected := []time.Time{
+		start,
+		start.Add(time.Second * 30),
+		start.Add(time.Second * 60),
+	}
+	assert.Equal(t, expected, spec.getTimes())
+}
+
+func TestTriggerResetsInterval(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		spec    = &resultWorkerSpec{tickRecorder: tickRecorder{clock: clock}}
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":           "60",
+		"worker_trigger_resets_interval": "true",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(spec.getTimes()) == 1 })
+	clock.BlockingAdvance(time.Second * 30)
+	worker.Trigger()
+	eventually(t, func() bool { return len(spec.getTimes()) == 2 })
+
+	clock.BlockingAdvance(time.Second * 30)
+	consistently(t, func() bool { return len(spec.getTimes()) == 2 })
+	clock.BlockingAdvance(time.Second * 30)
+	eventually(t, func() bool { return len(spec.getTimes()) == 3 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	expected := []time.Time{
+		start,
+		start.Add(time.Second * 30),
+		start.Add(time.Second * 90),
+	}
+	assert.Equal(t, expected, spec.getTimes())
+}
+
+func TestTriggerDuringRetry(t *testing.T) {
+	var (
+		clock    = glock.NewMockClock()
+		recorder = tickRecorder{clock: clock}
+		spec     = NewMockWorkerSpecFinalizer()
+		worker   = makeWorker(spec, clock)
+		errChan  = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		if recorder.record() == 0 {
+			return errors.New("oops")
+		}
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":       "3600",
+		"worker_retry_enabled":       "true",
+		"worker_retry_initial_delay": "10s",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return len(recorder.getTimes()) == 1 })
+	clock.BlockingAdvance(time.Second)
+	worker.Trigger()
+	eventually(t, func() bool { return len(recorder.getTimes()) == 2 })
+
+	// The triggered tick succeeded, so the pending retry is not resumed
+	clock.BlockingAdvance(time.Second * 9)
+	consistently(t, func() bool { return len(recorder.getTimes()) == 2 })
+	clock.BlockingAdvance(time.Hour - time.Second*9)
+	eventually(t, func() bool { return len(recorder.getTimes()) == 3 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+
+	expected := []time.Time{
+		start,
+		start.Add(time.Second),
+		start.Add(time.Hour + time.Second),
+	}
+	assert.Equal(t, expected, recorder.getTimes())
+}
+
+func TestTriggerCoalesce(t *testing.T) {
+	var (
+		spec        = NewMockWorkerSpecFinalizer()
+		clock       = glock.NewMockClock()
+		worker      = makeWorker(spec, clock)
+		tickChan    = make(chan struct{}, 1)
+		releaseChan = make(chan struct{})
+		errChan     = make(chan error)
+	)
+
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		<-releaseChan
+		return nil
+	})
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, receiveStruct(tickChan))
+	worker.Trigger()
+	worker.Trigger()
+	worker.Trigger()
+	close(releaseChan)
+
+	// Triggers during a tick result in a single follow-up tick
+	eventually(t, receiveStruct(tickChan))
+	assertStructChanDoesNotReceive(t, tickChan)
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.CalledN(t, spec.TickFunc, 2)
+}
+
+func TestTriggerSource(t *testing.T) {
+	var (
+		spec        = NewMockWorkerSpecFinalizer()
+		clock       = glock.NewMockClock()
+		triggerChan = make(chan struct{})
+		worker      = makeWorker(spec, clock, WithTriggerSource(triggerChan))
+		tickChan    = make(chan struct{}, 1)
+		errChan     = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, receiveStruct(tickChan))
+	assertStructChanDoesNotReceive(t, tickChan)
+	triggerChan <- struct{}{}
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
 func TestBadInject(t *testing.T) {
 	worker := NewWorker(&badInjectWorkerSpec{})
 	worker.Services = makeBadContainer()
 	worker.Health = nacelle.NewHealth()
 	worker.Config = testConfig
 
-	ctx := context.Background()
-	err := worker.Init(ctx)
-	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "ServiceA")
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "ServiceA")
+}
+
+func TestTagModifiers(t *testing.T) {
+	worker := NewWorker(NewMockWorkerSpecFinalizer(), WithTagModifiers(nacelle.NewEnvTagPrefixer("prefix")))
+	worker.Services = nacelle.NewServiceContainer()
+	worker.Health = nacelle.NewHealth()
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"prefix_worker_tick_interval": "3600",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, worker.tickInterval)
+}
+
+func TestInitConfig(t *testing.T) {
+	var (
+		spec   = NewMockWorkerSpecFinalizer()
+		clock  = glock.NewMockClock()
+		worker = makeWorker(spec, clock)
+	)
+
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "60",
+		"worker_strict_clock":  "true",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	assert.True(t, worker.strictClock)
+	assert.Equal(t, 60*time.Second, worker.tickInterval)
+}
+
+func TestInitError(t *testing.T) {
+	var (
+		spec   = NewMockWorkerSpecFinalizer()
+		worker = makeWorker(spec, glock.NewRealClock())
+	)
+
+	spec.InitFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.EqualError(t, err, "oops")
+}
+
+func TestFinalize(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.CalledOnce(t, spec.FinalizeFunc)
+}
+
+func TestFinalizeError(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	spec.FinalizeFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "oops")
+	mockassert.CalledOnce(t, spec.FinalizeFunc)
+}
+
+func TestFinalizeErrorDoesNotOverwrite(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+
+	spec.FinalizeFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("unheard oops")
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	// A worker stopped before its first tick never ticks
+	eventually(t, func() bool { return len(spec.TickFunc.History()) > 0 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "oops")
+	mockassert.CalledOnce(t, spec.FinalizeFunc)
+}
+
+func TestTickError(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "oops")
+}
+
+func TestTickErrorRetry(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	times := []time.Time{}
+	mutex := sync.Mutex{}
+
+	lockedLen := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(times)
+	}
+
+	start := time.Now()
+	clock.SetCurrent(start)
+
+	results := []error{
+		fmt.Errorf("oops"),
+		fmt.Errorf("oops"),
+		nil,
+		fmt.Errorf("oops"),
+		fmt.Errorf("oops"),
+		fmt.Errorf("final oops"),
+	}
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		times = append(times, clock.Now())
+		err := results[0]
+		results = results[1:]
+		return err
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":       "60",
+		"worker_retry_enabled":       "true",
+		"worker_retry_initial_delay": "1s",
+		"worker_retry_multiplier":    "2",
+		"worker_retry_max_attempts":  "3",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	clock.BlockingAdvance(time.Second)
+	clock.BlockingAdvance(time.Second * 2)
+	eventually(t, func() bool { return lockedLen() == 3 })
+	clock.BlockingAdvance(time.Minute)
+	clock.BlockingAdvance(time.Second)
+	clock.BlockingAdvance(time.Second * 2)
+
+	value := readErrorValue(t, errChan)
+	assert.EqualError(t, value, "final oops")
+
+	expected := []time.Time{
+		start,
+		start.Add(time.Second * 1),
+		start.Add(time.Second * 3),
+		start.Add(time.Second * 63),
+		start.Add(time.Second * 64),
+		start.Add(time.Second * 66),
+	}
+	assert.Equal(t, expected, times)
+}
+
+func TestTickErrorRetryStop(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		return fmt.Errorf("oops")
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_retry_enabled": "true",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	clock.BlockingAdvance(time.Second)
+	clock.BlockingAdvance(time.Second * 2)
+	clock.BlockingAdvance(time.Second * 4)
+	eventually(t, func() bool { return clock.BlockedOnAfter() == 1 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.CalledN(t, spec.TickFunc, 4)
+}
+
+func TestInitRetryConfigError(t *testing.T) {
+	worker := makeWorker(NewMockWorkerSpecFinalizer(), glock.NewMockClock())
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_retry_jitter": "sometimes",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	assert.NotNil(t, err)
+}
+
+func TestTickIntervalDuration(t *testing.T) {
+	var (
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock)
+		tickChan = make(chan struct{}, 1)
+		errChan  = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "1m30s",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, receiveStruct(tickChan))
+	clock.BlockingAdvance(time.Minute)
+	assertStructChanDoesNotReceive(t, tickChan)
+	clock.BlockingAdvance(time.Second * 30)
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestTickJitter(t *testing.T) {
+	testCases := []struct {
+		jitter   string
+		expected time.Duration
+	}{
+		{jitter: "10s", expected: time.Second * 65},
+		{jitter: "0.5", expected: time.Second * 75},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.jitter, func(t *testing.T) {
+			var (
+				spec     = NewMockWorkerSpecFinalizer()
+				clock    = glock.NewMockClock()
+				worker   = makeWorker(spec, clock, WithRandomSource(func() float64 { return 0.75 }))
+				tickChan = make(chan struct{}, 1)
+				errChan  = make(chan error)
+			)
+
+			spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+				tickChan <- struct{}{}
+				return nil
+			})
+			worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+				"worker_tick_interval": "60",
+				"worker_tick_jitter":   testCase.jitter,
+			}))
+
+			ctx := context.Background()
+			err := worker.Init(ctx)
+			require.Nil(t, err)
+
+			go func() {
+				errChan <- worker.Run(ctx)
+			}()
+
+			eventually(t, receiveStruct(tickChan))
+			clock.BlockingAdvance(testCase.expected - time.Second)
+			assertStructChanDoesNotReceive(t, tickChan)
+			clock.BlockingAdvance(time.Second)
+			eventually(t, receiveStruct(tickChan))
+
+			worker.Stop(ctx)
+			value := readErrorValue(t, errChan)
+			assert.Nil(t, value)
+		})
+	}
+}
+
+func TestInitialJitter(t *testing.T) {
+	var (
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock, WithRandomSource(func() float64 { return 0.25 }))
+		tickChan = make(chan struct{}, 1)
+		errChan  = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":  "60",
+		"worker_initial_jitter": "20s",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	assertStructChanDoesNotReceive(t, tickChan)
+	clock.BlockingAdvance(time.Second * 4)
+	assertStructChanDoesNotReceive(t, tickChan)
+	clock.BlockingAdvance(time.Second)
+	eventually(t, receiveStruct(tickChan))
+
+	// Subsequent ticks follow the regular interval
+	clock.BlockingAdvance(time.Second * 60)
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestFixedRate(t *testing.T) {
+	var (
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock)
+		tickChan = make(chan time.Time, 1)
+		errChan  = make(chan error)
+	)
+
+	start := time.Now()
+	clock.SetCurrent(start)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		clock.Advance(time.Second * 3)
+		tickChan <- ScheduledTimeFromContext(ctx)
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "10",
+		"worker_fixed_rate":    "true",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	// The duration of each tick does not shift the following slot
+	assert.Equal(t, start, <-tickChan)
+	clock.BlockingAdvance(time.Second * 7)
+	assert.Equal(t, start.Add(time.Second*10), <-tickChan)
+	clock.BlockingAdvance(time.Second * 7)
+	assert.Equal(t, start.Add(time.Second*20), <-tickChan)
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestFixedRateMissedTickPolicy(t *testing.T) {
+	testCases := []struct {
+		policy   string
+		expected []time.Duration
+		skipped  int
+	}{
+		{policy: MissedTickPolicySkip, expected: []time.Duration{0, time.Second * 30}, skipped: 2},
+		{policy: MissedTickPolicyRunOnce, expected: []time.Duration{0, time.Second * 20, time.Second * 30}, skipped: 1},
+		{policy: MissedTickPolicyRunAll, expected: []time.Duration{0, time.Second * 10, time.Second * 20, time.Second * 30}, skipped: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.policy, func(t *testing.T) {
+			var (
+				spec      = NewMockWorkerSpecFinalizer()
+				clock     = glock.NewMockClock()
+				collector = NewPrometheusCollector()
+				worker    = makeWorker(spec, clock, WithMetrics(collector))
+				tickChan  = make(chan time.Time, len(testCase.expected))
+				errChan   = make(chan error)
+			)
+
+			start := time.Now()
+			clock.SetCurrent(start)
+
+			spec.TickFunc.PushHook(func(ctx context.Context) error {
+				clock.Advance(time.Second * 25)
+				tickChan <- ScheduledTimeFromContext(ctx)
+				return nil
+			})
+			spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+				tickChan <- ScheduledTimeFromContext(ctx)
+				return nil
+			})
+			worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+				"worker_tick_interval":      "10",
+				"worker_fixed_rate":         "true",
+				"worker_missed_tick_policy": testCase.policy,
+			}))
+
+			ctx := context.Background()
+			err := worker.Init(ctx)
+			require.Nil(t, err)
+
+			go func() {
+				errChan <- worker.Run(ctx)
+			}()
+
+			eventually(t, func() bool { return len(tickChan) == len(testCase.expected)-1 })
+			clock.BlockingAdvance(time.Second * 5)
+			eventually(t, func() bool { return len(tickChan) == len(testCase.expected) })
+
+			worker.Stop(ctx)
+			value := readErrorValue(t, errChan)
+			assert.Nil(t, value)
+
+			for _, offset := range testCase.expected {
+				assert.Equal(t, start.Add(offset), <-tickChan)
+			}
+
+			buf := &bytes.Buffer{}
+			_, _ = collector.WriteTo(buf)
+			assert.Contains(t, buf.String(), fmt.Sprintf("workerbase_skipped_slots_total{worker=\"\"} %d\n", testCase.skipped))
+		})
+	}
+}
+
+func TestInitialDelay(t *testing.T) {
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		firstTickAt time.Duration
+	}{
+		{
+			name:        "delay",
+			env:         map[string]string{"worker_initial_delay": "30s"},
+			firstTickAt: time.Second * 30,
+		},
+		{
+			name:        "no tick on start",
+			env:         map[string]string{"worker_tick_on_start": "false"},
+			firstTickAt: time.Second * 60,
+		},
+		{
+			name:        "delay and no tick on start",
+			env:         map[string]string{"worker_initial_delay": "30s", "worker_tick_on_start": "false"},
+			firstTickAt: time.Second * 90,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				spec     = NewMockWorkerSpecFinalizer()
+				clock    = glock.NewMockClock()
+				worker   = makeWorker(spec, clock)
+				tickChan = make(chan struct{}, 1)
+				errChan  = make(chan error)
+			)
+
+			spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+				tickChan <- struct{}{}
+				return nil
+			})
+
+			env := map[string]string{"worker_tick_interval": "60"}
+			for key, value := range testCase.env {
+				env[key] = value
+			}
+			worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(env))
+
+			ctx := context.Background()
+			err := worker.Init(ctx)
+			require.Nil(t, err)
+
+			go func() {
+				errChan <- worker.Run(ctx)
+			}()
+
+			assertStructChanDoesNotReceive(t, tickChan)
+			clock.BlockingAdvance(testCase.firstTickAt - time.Second)
+			assertStructChanDoesNotReceive(t, tickChan)
+			clock.BlockingAdvance(time.Second)
+			eventually(t, receiveStruct(tickChan))
+
+			// Subsequent ticks follow the regular interval
+			clock.BlockingAdvance(time.Second * 60)
+			eventually(t, receiveStruct(tickChan))
+
+			worker.Stop(ctx)
+			value := readErrorValue(t, errChan)
+			assert.Nil(t, value)
+		})
+	}
+}
+
+func TestInitialDelayStop(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "60",
+		"worker_initial_delay": "1h",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	eventually(t, func() bool { return clock.BlockedOnAfter() == 1 })
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.NotCalled(t, spec.TickFunc)
+	mockassert.CalledOnce(t, spec.FinalizeFunc)
+}
+
+func TestInitialDelaySchedule(t *testing.T) {
+	var (
+		clock   = glock.NewMockClock()
+		spec    = &resultWorkerSpec{tickRecorder: tickRecorder{clock: clock}}
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	start := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock.SetCurrent(start)
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_schedule":          "*/10 * * * *",
+		"worker_schedule_timezone": "UTC",
+		"worker_initial_delay":     "15m",
+	}))
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	// The 12:10 activation falls within the delay
+	clock.BlockingAdvance(time.Minute * 10)
+	consistently(t, func() bool { return len(spec.getTimes()) == 0 })
+	clock.BlockingAdvance(time.Minute * 10)
+	eventually(t, func() bool { return len(spec.getTimes()) == 1 })
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	assert.Equal(t, []time.Time{start.Add(time.Minute * 20)}, spec.getTimes())
+}
+
+func TestInitTickJitterError(t *testing.T) {
+	for _, value := range []string{"1.5", "-0.5", "-5s", "lots"} {
+		worker := makeWorker(NewMockWorkerSpecFinalizer(), glock.NewMockClock())
+		worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+			"worker_tick_jitter": value,
+		}))
+
+		err := worker.Init(context.Background())
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "invalid tick jitter")
+	}
+}
+
+func TestInitTickIntervalError(t *testing.T) {
+	for _, value := range []string{"-5", "-1m", "often"} {
+		worker := makeWorker(NewMockWorkerSpecFinalizer(), glock.NewMockClock())
+		worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+			"worker_tick_interval": value,
+		}))
+
+		err := worker.Init(context.Background())
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "invalid tick interval")
+	}
 }
 
-func TestTagModifiers(t *testing.T) {
-	worker := NewWorker(NewMockWorkerSpecFinalizer(), WithTagModifiers(nacelle.NewEnvTagPrefixer("prefix")))
-	worker.Services = nacelle.NewServiceContainer()
-	worker.Health = nacelle.NewHealth()
+func TestTickTimeoutFatal(t *testing.T) {
+	var (
+		spec    = NewMockWorkerSpecFinalizer()
+		clock   = glock.NewMockClock()
+		worker  = makeWorker(spec, clock)
+		errChan = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
 	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
-		"prefix_worker_tick_interval": "3600",
+		"worker_tick_timeout":  "10s",
+		"worker_retry_enabled": "true",
 	}))
 
 	ctx := context.Background()
 	err := worker.Init(ctx)
+	require.Nil(t, err)
 
-	assert.Nil(t, err)
-	assert.Equal(t, time.Hour, worker.tickInterval)
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	clock.BlockingAdvance(time.Second * 10)
+	value := readErrorValue(t, errChan)
+
+	var timeoutErr *TickTimeoutError
+	require.True(t, errors.As(value, &timeoutErr))
+	assert.Equal(t, time.Second*10, timeoutErr.Timeout)
+	assert.True(t, errors.Is(value, context.DeadlineExceeded))
+	mockassert.CalledOnce(t, spec.TickFunc)
 }
 
-func TestInitConfig(t *testing.T) {
+func TestTickTimeoutSkip(t *testing.T) {
 	var (
-		spec   = NewMockWorkerSpecFinalizer()
-		clock  = glock.NewMockClock()
-		worker = makeWorker(spec, clock)
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock)
+		tickChan = make(chan struct{}, 1)
+		errChan  = make(chan error)
+		calls    int32
 	)
 
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-ctx.Done()
+			return nil
+		}
+
+		tickChan <- struct{}{}
+		return nil
+	})
 	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
-		"worker_tick_interval": "60",
-		"worker_strict_clock":  "true",
+		"worker_tick_interval":       "60",
+		"worker_tick_timeout":        "10s",
+		"worker_tick_timeout_policy": "skip",
 	}))
 
 	ctx := context.Background()
 	err := worker.Init(ctx)
 	require.Nil(t, err)
 
-	assert.True(t, worker.strictClock)
-	assert.Equal(t, 60*time.Second, worker.tickInterval)
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	clock.BlockingAdvance(time.Second * 10)
+	assertStructChanDoesNotReceive(t, tickChan)
+	clock.BlockingAdvance(time.Second * 60)
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
 }
 
-func TestInitError(t *testing.T) {
+func TestTickTimeoutRetry(t *testing.T) {
 	var (
-		spec   = NewMockWorkerSpecFinalizer()
-		worker = makeWorker(spec, glock.NewRealClock())
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock)
+		tickChan = make(chan struct{}, 1)
+		errChan  = make(chan error)
+		calls    int32
 	)
 
-	spec.InitFunc.SetDefaultHook(func(ctx context.Context) error {
-		return fmt.Errorf("oops")
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		tickChan <- struct{}{}
+		return nil
 	})
-	worker.Config = testConfig
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":       "60",
+		"worker_tick_timeout":        "10s",
+		"worker_tick_timeout_policy": "retry",
+	}))
 
 	ctx := context.Background()
 	err := worker.Init(ctx)
-	assert.EqualError(t, err, "oops")
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	// Retried immediately without waiting for the tick interval
+	clock.BlockingAdvance(time.Second * 10)
+	eventually(t, receiveStruct(tickChan))
+
+	worker.Stop(ctx)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
 }
 
-func TestFinalize(t *testing.T) {
+func TestTickPanicFatal(t *testing.T) {
 	var (
 		spec    = NewMockWorkerSpecFinalizer()
 		clock   = glock.NewMockClock()
 		worker  = makeWorker(spec, clock)
 		errChan = make(chan error)
 	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		panic("oops")
+	})
 	worker.Config = testConfig
 
 	ctx := context.Background()
 	err := worker.Init(ctx)
-	assert.Nil(t, err)
+	require.Nil(t, err)
 
 	go func() {
 		errChan <- worker.Run(ctx)
 	}()
 
-	worker.Stop(ctx)
 	value := readErrorValue(t, errChan)
-	assert.Nil(t, value)
+	require.NotNil(t, value)
+
+	var panicErr *PanicError
+	require.True(t, errors.As(value, &panicErr))
+	assert.Equal(t, "oops", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "invokeTick")
 	mockassert.CalledOnce(t, spec.FinalizeFunc)
 }
 
-func TestFinalizeError(t *testing.T) {
+func TestTickPanicContinue(t *testing.T) {
 	var (
-		spec    = NewMockWorkerSpecFinalizer()
-		clock   = glock.NewMockClock()
-		worker  = makeWorker(spec, clock)
-		errChan = make(chan error)
+		spec     = NewMockWorkerSpecFinalizer()
+		clock    = glock.NewMockClock()
+		worker   = makeWorker(spec, clock)
+		tickChan = make(chan struct{}, 1)
+		errChan  = make(chan error)
 	)
 
-	spec.FinalizeFunc.SetDefaultHook(func(ctx context.Context) error {
-		return fmt.Errorf("oops")
+	spec.TickFunc.PushHook(func(ctx context.Context) error {
+		panic(fmt.Errorf("oops"))
 	})
-	worker.Config = testConfig
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		tickChan <- struct{}{}
+		return nil
+	})
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval":               "60",
+		"worker_panic_policy":                "continue",
+		"worker_unhealthy_failure_threshold": "1",
+	}))
 
 	ctx := context.Background()
 	err := worker.Init(ctx)
-	assert.Nil(t, err)
+	require.Nil(t, err)
 
 	go func() {
 		errChan <- worker.Run(ctx)
 	}()
 
+	// Panicking tick counts as a failure
+	eventually(t, func() bool { return !worker.healthStatus.Healthy() })
+	assertStructChanDoesNotReceive(t, tickChan)
+
+	clock.BlockingAdvance(time.Second * 60)
+	eventually(t, receiveStruct(tickChan))
+	eventually(t, func() bool { return worker.healthStatus.Healthy() })
+
 	worker.Stop(ctx)
 	value := readErrorValue(t, errChan)
-	assert.EqualError(t, value, "oops")
-	mockassert.CalledOnce(t, spec.FinalizeFunc)
+	assert.Nil(t, value)
 }
 
-func TestFinalizeErrorDoesNotOverwrite(t *testing.T) {
+func TestInitPanicPolicyError(t *testing.T) {
+	var (
+		spec   = NewMockWorkerSpecFinalizer()
+		clock  = glock.NewMockClock()
+		worker = makeWorker(spec, clock)
+	)
+
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_panic_policy": "ignore",
+	}))
+
+	err := worker.Init(context.Background())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown panic policy")
+}
+
+func TestStopBeforeRun(t *testing.T) {
+	var (
+		spec   = NewMockWorkerSpecFinalizer()
+		clock  = glock.NewMockClock()
+		worker = makeWorker(spec, clock)
+	)
+
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	stopChan := make(chan error)
+	go func() { stopChan <- worker.Stop(ctx) }()
+
+	value := readErrorValue(t, stopChan)
+	assert.Nil(t, value)
+	mockassert.NotCalled(t, spec.TickFunc)
+}
+
+func TestRunAfterStop(t *testing.T) {
 	var (
 		spec    = NewMockWorkerSpecFinalizer()
 		clock   = glock.NewMockClock()
@@ -323,52 +1788,148 @@ func TestFinalizeErrorDoesNotOverwrite(t *testing.T) {
 		errChan = make(chan error)
 	)
 
+	worker.Config = testConfig
+
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+	require.Nil(t, worker.Stop(ctx))
+
+	go func() { errChan <- worker.Run(ctx) }()
+
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+	mockassert.NotCalled(t, spec.TickFunc)
+	mockassert.CalledOnce(t, spec.FinalizeFunc)
+}
+
+func TestStopContextDeadline(t *testing.T) {
+	var (
+		spec        = NewMockWorkerSpecFinalizer()
+		clock       = glock.NewMockClock()
+		worker      = makeWorker(spec, clock)
+		tickChan    = make(chan struct{})
+		releaseChan = make(chan struct{})
+		errChan     = make(chan error)
+	)
+
 	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
-		return fmt.Errorf("oops")
+		close(tickChan)
+		<-releaseChan
+		return nil
 	})
+	worker.Config = testConfig
 
-	spec.FinalizeFunc.SetDefaultHook(func(ctx context.Context) error {
-		return fmt.Errorf("unheard oops")
+	ctx := context.Background()
+	err := worker.Init(ctx)
+	require.Nil(t, err)
+
+	go func() {
+		errChan <- worker.Run(ctx)
+	}()
+
+	<-tickChan
+
+	stopCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	// Tick ignores its context; Stop gives up on the caller's deadline
+	err = worker.Stop(stopCtx)
+	assert.Equal(t, context.Canceled, err)
+
+	close(releaseChan)
+	value := readErrorValue(t, errChan)
+	assert.Nil(t, value)
+}
+
+func TestStopDrain(t *testing.T) {
+	var (
+		spec        = NewMockWorkerSpecFinalizer()
+		clock       = glock.NewMockClock()
+		worker      = makeWorker(spec, clock)
+		tickChan    = make(chan struct{})
+		releaseChan = make(chan struct{})
+		ctxErrChan  = make(chan error, 1)
+		errChan     = make(chan error)
+	)
+
+	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
+		close(tickChan)
+
+		select {
+		case <-releaseChan:
+		case <-ctx.Done():
+		}
+
+		ctxErrChan <- ctx.Err()
+		return nil
 	})
-	worker.Config = testConfig
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "5",
+		"worker_drain_period":  "30s",
+	}))
 
 	ctx := context.Background()
 	err := worker.Init(ctx)
-	assert.Nil(t, err)
+	require.Nil(t, err)
 
 	go func() {
 		errChan <- worker.Run(ctx)
 	}()
 
-	worker.Stop(ctx)
+	<-tickChan
+	go worker.Stop(ctx)
+
+	// Tick context remains live during the drain period
+	eventually(t, func() bool { return clock.BlockedOnAfter() == 1 })
+	consistently(t, func() bool { return len(ctxErrChan) == 0 })
+
+	close(releaseChan)
+	assert.Nil(t, <-ctxErrChan)
+
 	value := readErrorValue(t, errChan)
-	assert.EqualError(t, value, "oops")
-	mockassert.CalledOnce(t, spec.FinalizeFunc)
+	assert.Nil(t, value)
 }
 
-func TestTickError(t *testing.T) {
+func TestStopDrainElapsed(t *testing.T) {
 	var (
-		spec    = NewMockWorkerSpecFinalizer()
-		clock   = glock.NewMockClock()
-		worker  = makeWorker(spec, clock)
-		errChan = make(chan error)
+		spec       = NewMockWorkerSpecFinalizer()
+		clock      = glock.NewMockClock()
+		worker     = makeWorker(spec, clock)
+		tickChan   = make(chan struct{})
+		ctxErrChan = make(chan error, 1)
+		errChan    = make(chan error)
 	)
 
 	spec.TickFunc.SetDefaultHook(func(ctx context.Context) error {
-		return fmt.Errorf("oops")
+		close(tickChan)
+		<-ctx.Done()
+		ctxErrChan <- ctx.Err()
+		return nil
 	})
-	worker.Config = testConfig
+	worker.Config = nacelle.NewConfig(nacelle.NewTestEnvSourcer(map[string]string{
+		"worker_tick_interval": "5",
+		"worker_drain_period":  "30s",
+	}))
 
 	ctx := context.Background()
 	err := worker.Init(ctx)
-	assert.Nil(t, err)
+	require.Nil(t, err)
 
 	go func() {
 		errChan <- worker.Run(ctx)
 	}()
 
+	<-tickChan
+	go worker.Stop(ctx)
+
+	clock.BlockingAdvance(time.Second * 29)
+	consistently(t, func() bool { return len(ctxErrChan) == 0 })
+	clock.BlockingAdvance(time.Second)
+	assert.Equal(t, context.Canceled, <-ctxErrChan)
+
 	value := readErrorValue(t, errChan)
-	assert.EqualError(t, value, "oops")
+	assert.Nil(t, value)
 }
 
 func TestTickContext(t *testing.T) {
@@ -398,8 +1959,8 @@ func TestTickContext(t *testing.T) {
 	assert.Nil(t, value)
 }
 
-func makeWorker(spec WorkerSpec, clock glock.Clock) *Worker {
-	worker := newWorker(spec, clock)
+func makeWorker(spec WorkerSpec, clock glock.Clock, configs ...ConfigFunc) *Worker {
+	worker := newWorker(spec, nil, clock, configs...)
 	worker.Services = nacelle.NewServiceContainer()
 	worker.Health = nacelle.NewHealth()
 	return worker
@@ -423,3 +1984,67 @@ func makeBadContainer() *nacelle.ServiceContainer {
 	container.Set("A", &B{})
 	return container
 }
+
+//
+// Adaptive Intervals
+
+type tickRecorder struct {
+	clock glock.Clock
+	times []time.Time
+	mutex sync.Mutex
+}
+
+func (r *tickRecorder) record() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.times = append(r.times, r.clock.Now())
+	return len(r.times) - 1
+}
+
+func (r *tickRecorder) getTimes() []time.Time {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]time.Time(nil), r.times...)
+}
+
+type nextIntervalWorkerSpec struct {
+	tickRecorder
+	intervals []time.Duration
+	next      time.Duration
+}
+
+func (s *nextIntervalWorkerSpec) Init(ctx context.Context) error { return nil }
+
+func (s *nextIntervalWorkerSpec) Tick(ctx context.Context) error {
+	s.next = time.Second * 5
+	if i := s.record(); i < len(s.intervals) {
+		s.next = s.intervals[i]
+	}
+
+	return nil
+}
+
+func (s *nextIntervalWorkerSpec) NextInterval() time.Duration {
+	return s.next
+}
+
+type resultWorkerSpec struct {
+	tickRecorder
+	results []TickResult
+}
+
+func (s *resultWorkerSpec) Init(ctx context.Context) error { return nil }
+
+func (s *resultWorkerSpec) Tick(ctx context.Context) error {
+	panic("unexpected call to Tick")
+}
+
+func (s *resultWorkerSpec) TickWithResult(ctx context.Context) (TickResult, error) {
+	if i := s.record(); i < len(s.results) {
+		return s.results[i], nil
+	}
+
+	return TickResult{}, nil
+}